@@ -0,0 +1,36 @@
+// Command k8sctl is a small kubectl-like CLI used to explore the different
+// client-go client types (typed, dynamic, informers) against a live cluster.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/client-go/util/exec"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/cmd"
+)
+
+func main() {
+	// Cancel the command context on Ctrl-C so watches and streams stop cleanly.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.NewRootCmd().ExecuteContext(ctx); err != nil {
+		// Commands such as exec and diff report their result as an exit code.
+		// Only diff's "differences found" is a result without a message.
+		code := 1
+		var exitErr exec.CodeExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.Code
+		}
+		if !cmd.IsDifferences(err) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		os.Exit(code)
+	}
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/manifest"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// Results of applying a single object.
+const (
+	resultCreated    = "created"
+	resultConfigured = "configured"
+	resultUnchanged  = "unchanged"
+)
+
+// Paths an object can be applied through.
+const (
+	pathTyped   = "typed"
+	pathDynamic = "dynamic"
+)
+
+// applyOptions holds the flags of the apply command.
+type applyOptions struct {
+	factory   *kube.Factory
+	filenames []string
+	output    string
+}
+
+// applyOutcome is the result of applying a single object.
+type applyOutcome struct {
+	// object is the object as returned by the API server.
+	object runtime.Object
+	result string
+	path   string
+}
+
+func newApplyCmd(f *kube.Factory) *cobra.Command {
+	o := &applyOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "apply -f FILENAME",
+		Short: "Create or update objects from YAML/JSON manifests",
+		Long: `Apply creates every object of the manifests, or updates it when it already
+exists. Kinds known to the client-go scheme (ConfigMaps, Deployments, ...) are
+sent through the typed clientset; everything else, CRDs included, goes through
+the dynamic client. Fields unknown to a typed kind are rejected rather than
+dropped.
+
+Existing objects are replaced with an Update, so fields set by other field
+managers and missing from the manifest are removed. Use ssa to only take over
+the fields of the manifest.`,
+		Example: `  k8sctl apply -f app.yaml
+  k8sctl apply -f manifests/
+  cat app.yaml | k8sctl apply -f -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := newObjectPrinter(cmd, o.output, outputFormatsObjects)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), p)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&o.filenames, "filename", "f", nil, "Manifest file, directory or - for stdin")
+	addOutputFlag(cmd, &o.output, outputFormatsObjects)
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// run applies the manifests. With -o the resulting objects are printed by p,
+// otherwise one summary line per object is written to out.
+func (o *applyOptions) run(ctx context.Context, in io.Reader, out, errOut io.Writer, p printer.Printer) error {
+	objects, err := manifest.ReadPaths(o.filenames, in)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	// Every object is attempted, failures are reported and counted so one
+	// bad document does not hide the result of the others.
+	counts := map[string]int{}
+	failed := 0
+	for _, obj := range objects {
+		outcome, err := o.apply(ctx, obj.Unstructured, namespace)
+		ref := printer.KindName(obj.Unstructured)
+		if err != nil {
+			failed++
+			fmt.Fprintf(errOut, "%s failed (%s): %v\n", ref, obj.Source, err)
+			continue
+		}
+		counts[outcome.result]++
+		if p != nil {
+			// Typed clients return objects without apiVersion/kind.
+			outcome.object.GetObjectKind().SetGroupVersionKind(obj.GroupVersionKind())
+			if err := p.PrintObjects([]runtime.Object{outcome.object}); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(out, "%s %s (%s)\n", ref, outcome.result, outcome.path)
+	}
+
+	if p != nil {
+		if err := p.Flush(); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(out, "\n%d created, %d configured, %d unchanged, %d failed\n",
+			counts[resultCreated], counts[resultConfigured], counts[resultUnchanged], failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to apply", failed, len(objects))
+	}
+	return nil
+}
+
+// apply routes the object to the typed clientset when its kind is known to
+// the client-go scheme and handled by applyTypedObject, and to the dynamic
+// client otherwise.
+func (o *applyOptions) apply(ctx context.Context, obj *unstructured.Unstructured, namespace string) (*applyOutcome, error) {
+	if obj.GetNamespace() != "" {
+		namespace = obj.GetNamespace()
+	}
+
+	typed, err := scheme.Scheme.New(obj.GroupVersionKind())
+	if err == nil {
+		// A lenient conversion would silently drop unknown or misspelled
+		// fields, which the dynamic path sends to the API server.
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.Object, typed, true); err != nil {
+			return nil, err
+		}
+		cs, err := o.factory.ClientSet()
+		if err != nil {
+			return nil, err
+		}
+		live, result, err := applyTypedObject(ctx, cs, typed, namespace)
+		if !errors.Is(err, errNoTypedClient) {
+			if err != nil {
+				return nil, err
+			}
+			return &applyOutcome{object: live, result: result, path: pathTyped}, nil
+		}
+	}
+
+	live, result, err := o.applyDynamic(ctx, obj, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &applyOutcome{object: live, result: result, path: pathDynamic}, nil
+}
+
+// errNoTypedClient is returned by applyTypedObject for kinds that are known
+// to the scheme but not wired to a typed client below.
+var errNoTypedClient = errors.New("no typed client for kind")
+
+// applyTypedObject applies obj through the matching typed client and returns
+// the resulting object. The namespace is ignored for cluster scoped kinds.
+func applyTypedObject(ctx context.Context, cs kubernetes.Interface, obj runtime.Object, namespace string) (runtime.Object, string, error) {
+	switch obj := obj.(type) {
+	case *corev1.Namespace:
+		return applyTyped(ctx, cs.CoreV1().Namespaces(), obj)
+	case *corev1.ConfigMap:
+		return applyTyped(ctx, cs.CoreV1().ConfigMaps(namespace), obj)
+	case *corev1.Secret:
+		return applyTyped(ctx, cs.CoreV1().Secrets(namespace), obj)
+	case *corev1.Service:
+		return applyTyped(ctx, cs.CoreV1().Services(namespace), obj)
+	case *corev1.ServiceAccount:
+		return applyTyped(ctx, cs.CoreV1().ServiceAccounts(namespace), obj)
+	case *corev1.Pod:
+		return applyTyped(ctx, cs.CoreV1().Pods(namespace), obj)
+	case *appsv1.Deployment:
+		return applyTyped(ctx, cs.AppsV1().Deployments(namespace), obj)
+	case *appsv1.StatefulSet:
+		return applyTyped(ctx, cs.AppsV1().StatefulSets(namespace), obj)
+	case *appsv1.DaemonSet:
+		return applyTyped(ctx, cs.AppsV1().DaemonSets(namespace), obj)
+	case *batchv1.Job:
+		return applyTyped(ctx, cs.BatchV1().Jobs(namespace), obj)
+	case *batchv1.CronJob:
+		return applyTyped(ctx, cs.BatchV1().CronJobs(namespace), obj)
+	case *rbacv1.Role:
+		return applyTyped(ctx, cs.RbacV1().Roles(namespace), obj)
+	case *rbacv1.RoleBinding:
+		return applyTyped(ctx, cs.RbacV1().RoleBindings(namespace), obj)
+	case *rbacv1.ClusterRole:
+		return applyTyped(ctx, cs.RbacV1().ClusterRoles(), obj)
+	case *rbacv1.ClusterRoleBinding:
+		return applyTyped(ctx, cs.RbacV1().ClusterRoleBindings(), obj)
+	}
+	return nil, "", errNoTypedClient
+}
+
+// typedObject is implemented by the pointer types of the built-in API
+// objects, e.g. *corev1.ConfigMap.
+type typedObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// typedClient is the subset of the generated typed clients used by apply.
+// Every generated client (ConfigMaps(ns), Deployments(ns), ...) satisfies it
+// for its own object type.
+type typedClient[T typedObject] interface {
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+}
+
+// applyTyped creates obj, or updates it on top of the live resourceVersion
+// when it already exists.
+func applyTyped[T typedObject](ctx context.Context, client typedClient[T], obj T) (runtime.Object, string, error) {
+	created, err := client.Create(ctx, obj, metav1.CreateOptions{})
+	if err == nil {
+		return created, resultCreated, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, "", err
+	}
+
+	live, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	obj.SetResourceVersion(live.GetResourceVersion())
+	updated, err := client.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, updateResult(live.GetResourceVersion(), updated.GetResourceVersion()), nil
+}
+
+// applyDynamic does the same as applyTyped through the dynamic client.
+func (o *applyOptions) applyDynamic(ctx context.Context, obj *unstructured.Unstructured, namespace string) (runtime.Object, string, error) {
+	mapping, err := o.factory.MappingFor(obj.GroupVersionKind())
+	if err != nil {
+		return nil, "", err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	created, err := client.Create(ctx, obj, metav1.CreateOptions{})
+	if err == nil {
+		return created, resultCreated, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, "", err
+	}
+
+	live, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	obj.SetResourceVersion(live.GetResourceVersion())
+	updated, err := client.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, updateResult(live.GetResourceVersion(), updated.GetResourceVersion()), nil
+}
+
+// updateResult reports "unchanged" when the API server did not bump the
+// resourceVersion, i.e. the update was a no-op.
+func updateResult(before, after string) string {
+	if before == after {
+		return resultUnchanged
+	}
+	return resultConfigured
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+func TestApplyRejectsUnknownTypedFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web"},
+		"spec": map[string]any{
+			"replicaz": int64(3),
+			"template": map[string]any{"spec": map[string]any{"containerz": []any{}}},
+		},
+	}}
+
+	// The conversion fails before any client is needed.
+	o := &applyOptions{factory: &kube.Factory{}}
+	_, err := o.apply(context.Background(), obj, "default")
+	for _, want := range []string{`unknown field "spec.replicaz"`, `unknown field "spec.template.spec.containerz"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("apply() error = %v, want it to contain %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// Client strategies compared by bench list.
+const (
+	strategyTyped    = "typed"
+	strategyDynamic  = "dynamic"
+	strategyMetadata = "metadata"
+)
+
+// benchListOptions holds the flags of the bench list command.
+type benchListOptions struct {
+	factory       *kube.Factory
+	strategies    []string
+	iterations    int
+	allNamespaces bool
+	chunkSize     int64
+}
+
+// listFunc lists all objects once and returns how many there were.
+type listFunc func(ctx context.Context) (int, error)
+
+// benchResult holds the measurements of one strategy.
+type benchResult struct {
+	strategy      string
+	objects       int
+	latencies     []time.Duration
+	allocs        uint64
+	allocBytes    uint64
+	responseBytes int64
+}
+
+func newBenchCmd(f *kube.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure the cost of the different client types",
+	}
+	cmd.AddCommand(newBenchListCmd(f))
+	return cmd
+}
+
+func newBenchListCmd(f *kube.Factory) *cobra.Command {
+	o := &benchListOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "list <resource>",
+		Short: "Compare listing a resource with the typed, dynamic and metadata clients",
+		Long: `Bench list lists the same resource repeatedly with every selected client
+and prints a comparison table:
+
+  typed     the generated clientset, decoding into Go structs (pods,
+            deployments and services only)
+  dynamic   the dynamic client, decoding into unstructured maps
+  metadata  the metadata client, which asks the server for
+            PartialObjectMetadata and so only transfers names, labels,
+            annotations, owners and the like
+
+Latency is measured per full list (all pages). Allocations and allocated bytes
+are the process wide Go heap counters per list, response bytes the size of the
+decoded response bodies per list. One untimed warm-up list per strategy fills
+the discovery cache and opens the connection.`,
+		Example: `  k8sctl bench list pods -A
+  k8sctl bench list deployments --strategy=typed,metadata --iterations=50`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.strategies, "strategy", []string{strategyTyped, strategyDynamic, strategyMetadata}, "Client strategies to compare: typed, dynamic, metadata")
+	cmd.Flags().IntVar(&o.iterations, "iterations", 10, "Timed lists per strategy")
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "List objects across all namespaces")
+	addChunkSizeFlag(cmd, &o.chunkSize)
+
+	return cmd
+}
+
+func (o *benchListOptions) run(ctx context.Context, out io.Writer, resource string) error {
+	if o.iterations < 1 {
+		return fmt.Errorf("--iterations must be at least 1")
+	}
+	namespace, err := resolveNamespace(o.factory, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+
+	var results []benchResult
+	for _, strategy := range o.strategies {
+		// Every strategy gets its own clients and connection, with a
+		// transport counting the response bytes.
+		var responseBytes atomic.Int64
+		cfg, err := o.factory.RESTConfig()
+		if err != nil {
+			return err
+		}
+		cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &countingTransport{next: rt, count: &responseBytes}
+		})
+
+		list, err := o.listFunc(cfg, strategy, resource, namespace)
+		if err != nil {
+			return err
+		}
+		if _, err := list(ctx); err != nil {
+			return fmt.Errorf("%s: %w", strategy, err)
+		}
+
+		responseBytes.Store(0)
+		result, err := measure(ctx, strategy, o.iterations, list)
+		if err != nil {
+			return fmt.Errorf("%s: %w", strategy, err)
+		}
+		result.responseBytes = responseBytes.Load()
+		results = append(results, result)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "STRATEGY\tOBJECTS\tMIN\tP50\tP95\tMAX\tALLOCS/OP\tALLOC BYTES/OP\tRESPONSE BYTES/OP")
+	for _, r := range results {
+		n := uint64(len(r.latencies))
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", r.strategy, r.objects,
+			r.latencies[0].Round(time.Microsecond), percentile(r.latencies, 50), percentile(r.latencies, 95),
+			r.latencies[len(r.latencies)-1].Round(time.Microsecond),
+			r.allocs/n, formatBytes(int64(r.allocBytes/n)), formatBytes(r.responseBytes/int64(n)))
+	}
+	return w.Flush()
+}
+
+// listFunc returns the list call of strategy built on cfg.
+func (o *benchListOptions) listFunc(cfg *rest.Config, strategy, resource, namespace string) (listFunc, error) {
+	opts := metav1.ListOptions{Limit: o.chunkSize}
+
+	switch strategy {
+	case strategyTyped:
+		res, ok := typedResources[strings.ToLower(resource)]
+		if !ok {
+			return nil, fmt.Errorf("the typed strategy supports pods, deployments and services, not %q", resource)
+		}
+		cs, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) (int, error) {
+			count, opts := 0, opts
+			for {
+				page, err := res.list(ctx, cs, namespace, opts)
+				if err != nil {
+					return 0, pagingError(err)
+				}
+				count += len(page.objects)
+				if page.continueToken == "" {
+					return count, nil
+				}
+				opts.Continue = page.continueToken
+			}
+		}, nil
+
+	case strategyDynamic:
+		mapping, err := o.factory.ResolveResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		dc, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var client dynamic.ResourceInterface = dc.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			client = dc.Resource(mapping.Resource).Namespace(namespace)
+		}
+		return func(ctx context.Context) (int, error) {
+			count := 0
+			err := listPages(ctx, client, opts, func(list *unstructured.UnstructuredList) error {
+				count += len(list.Items)
+				return nil
+			})
+			return count, err
+		}, nil
+
+	case strategyMetadata:
+		mapping, err := o.factory.ResolveResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		mc, err := metadata.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var client metadata.ResourceInterface = mc.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			client = mc.Resource(mapping.Resource).Namespace(namespace)
+		}
+		return func(ctx context.Context) (int, error) {
+			count, opts := 0, opts
+			for {
+				list, err := client.List(ctx, opts)
+				if err != nil {
+					return 0, pagingError(err)
+				}
+				count += len(list.Items)
+				if list.Continue == "" {
+					return count, nil
+				}
+				opts.Continue = list.Continue
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q, expected typed, dynamic or metadata", strategy)
+}
+
+// measure runs list iterations times and records the latency and heap
+// allocations of every run. The latencies are returned sorted.
+func measure(ctx context.Context, strategy string, iterations int, list listFunc) (benchResult, error) {
+	result := benchResult{strategy: strategy}
+	var before, after runtime.MemStats
+	for range iterations {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		count, err := list(ctx)
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			return result, err
+		}
+		result.objects = count
+		result.latencies = append(result.latencies, elapsed)
+		result.allocs += after.Mallocs - before.Mallocs
+		result.allocBytes += after.TotalAlloc - before.TotalAlloc
+	}
+	slices.Sort(result.latencies)
+	return result, nil
+}
+
+// percentile returns the p-th percentile of sorted latencies, nearest rank,
+// or 0 for no latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[min(max(rank, 1), len(sorted))-1].Round(time.Microsecond)
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. 1.5MiB. The unit
+// is chosen after rounding, so 1048575 bytes are 1.0MiB rather than 1024.0KiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	const units = "KMGTPE"
+	v, exp := float64(n)/unit, 0
+	for v >= unit-0.05 && exp < len(units)-1 {
+		v /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", v, units[exp])
+}
+
+// countingTransport adds the size of every response body to count.
+type countingTransport struct {
+	next  http.RoundTripper
+	count *atomic.Int64
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingReader{ReadCloser: resp.Body, count: t.count}
+	return resp, nil
+}
+
+type countingReader struct {
+	io.ReadCloser
+	count *atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
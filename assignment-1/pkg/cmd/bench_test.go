@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	ms := func(n ...int) []time.Duration {
+		out := make([]time.Duration, len(n))
+		for i, v := range n {
+			out[i] = time.Duration(v) * time.Millisecond
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      int
+		want   time.Duration
+	}{
+		{name: "empty sample", sorted: nil, p: 50, want: 0},
+		{name: "single sample p50", sorted: ms(7), p: 50, want: 7 * time.Millisecond},
+		{name: "single sample p95", sorted: ms(7), p: 95, want: 7 * time.Millisecond},
+		{name: "p0 is the minimum", sorted: ms(1, 2, 3), p: 0, want: time.Millisecond},
+		{name: "p100 is the maximum", sorted: ms(1, 2, 3), p: 100, want: 3 * time.Millisecond},
+		{name: "p50 of even sample", sorted: ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), p: 50, want: 5 * time.Millisecond},
+		{name: "p95 of ten samples", sorted: ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), p: 95, want: 10 * time.Millisecond},
+		{name: "p50 of odd sample", sorted: ms(1, 2, 3), p: 50, want: 2 * time.Millisecond},
+		{name: "rounded to microseconds", sorted: []time.Duration{1234567 * time.Nanosecond}, p: 50, want: 1235 * time.Microsecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %d) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{n: 0, want: "0B"},
+		{n: 1023, want: "1023B"},
+		{n: 1024, want: "1.0KiB"},
+		{n: 1536, want: "1.5KiB"},
+		{n: 1024*1024 - 52, want: "1023.9KiB"},
+		{n: 1024*1024 - 1, want: "1.0MiB"},
+		{n: 1024 * 1024, want: "1.0MiB"},
+		{n: 5 * 1024 * 1024 * 1024 / 2, want: "2.5GiB"},
+		{n: 1 << 60, want: "1.0EiB"},
+		{n: math.MaxInt64, want: "8.0EiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatBytes(tt.n); got != tt.want {
+				t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
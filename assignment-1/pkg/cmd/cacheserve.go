@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// ownerIndex indexes cached objects by the UIDs of their owners, so that the
+// dependents of an object are found without scanning the whole cache.
+const ownerIndex = "owner"
+
+// cacheServeOptions holds the flags of the cache-serve command.
+type cacheServeOptions struct {
+	factory       *kube.Factory
+	listen        string
+	allNamespaces bool
+	resync        time.Duration
+}
+
+// cachedResource is one resource served from an informer cache.
+type cachedResource struct {
+	resource   schema.GroupResource
+	namespaced bool
+	informer   cache.SharedIndexInformer
+	lister     cache.GenericLister
+}
+
+func newCacheServeCmd(f *kube.Factory) *cobra.Command {
+	o := &cacheServeOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "cache-serve <resource>...",
+		Short: "Serve a local read-only HTTP API from informer caches",
+		Long: `Cache-serve starts one shared informer per resource and, once the caches
+synced, serves their contents over HTTP. Reads never reach the API server:
+they are answered from the informer indexers through listers, so scripts can
+poll at high rates without loading the cluster.
+
+  GET /healthz                                  ok once all caches synced
+  GET /api                                      served resources and object counts
+  GET /api/<resource>                           list, as a v1 List
+  GET /api/<resource>/<name>                    cluster scoped object
+  GET /api/<resource>/<namespace>/<name>        namespaced object
+
+Lists accept ?namespace=, ?labelSelector= and ?owner=<uid>, the latter
+answered from an index of the owner references.
+
+Resources are addressed by their plural name, so resources of different
+groups sharing a plural (events and events.events.k8s.io) cannot be served
+together.`,
+		Example: `  k8sctl cache-serve pods deployments -A
+  curl 'localhost:8080/api/pods?labelSelector=app=web'
+  curl localhost:8080/api/deployments/default/web`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.ErrOrStderr(), args)
+		},
+	}
+	cmd.Flags().StringVar(&o.listen, "listen", "127.0.0.1:8080", "Address the HTTP server listens on")
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "Cache objects across all namespaces")
+	cmd.Flags().DurationVar(&o.resync, "resync", 0, "Informer resync period, 0 disables resync")
+
+	return cmd
+}
+
+func (o *cacheServeOptions) run(ctx context.Context, log io.Writer, args []string) error {
+	namespace, err := resolveNamespace(o.factory, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+	dc, err := o.factory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dc, o.resync, namespace, nil)
+
+	resources := map[string]*cachedResource{}
+	for _, arg := range args {
+		mapping, err := o.factory.ResolveResource(arg)
+		if err != nil {
+			return err
+		}
+		// Resources are served under their plural alone, so two groups with
+		// the same plural, such as events and events.events.k8s.io, would
+		// shadow each other.
+		if existing, ok := resources[mapping.Resource.Resource]; ok {
+			if existing.resource == mapping.Resource.GroupResource() {
+				continue
+			}
+			return fmt.Errorf("%s and %s are both served as /api/%s, cache only one of them",
+				existing.resource, mapping.Resource.GroupResource(), mapping.Resource.Resource)
+		}
+		informer := factory.ForResource(mapping.Resource)
+		// Indexers must be added before the informer starts.
+		if err := informer.Informer().AddIndexers(cache.Indexers{ownerIndex: indexByOwner}); err != nil {
+			return err
+		}
+		resources[mapping.Resource.Resource] = &cachedResource{
+			resource:   mapping.Resource.GroupResource(),
+			namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+			informer:   informer.Informer(),
+			lister:     informer.Lister(),
+		}
+	}
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+
+	start := time.Now()
+	for gvr, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			// Interrupted before the initial list completed.
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("cache for %s did not sync", gvr)
+		}
+	}
+	fmt.Fprintf(log, "caches synced in %s\n", time.Since(start).Round(time.Millisecond))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("GET /api", func(w http.ResponseWriter, r *http.Request) {
+		counts := map[string]int{}
+		for name, res := range resources {
+			counts[name] = len(res.informer.GetIndexer().ListKeys())
+		}
+		writeJSON(w, http.StatusOK, counts)
+	})
+	mux.HandleFunc("GET /api/{resource}", func(w http.ResponseWriter, r *http.Request) {
+		res, ok := resources[r.PathValue("resource")]
+		if !ok {
+			writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: "resources"}, r.PathValue("resource")))
+			return
+		}
+		res.serveList(w, r)
+	})
+	mux.HandleFunc("GET /api/{resource}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		res, ok := resources[r.PathValue("resource")]
+		if !ok {
+			writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: "resources"}, r.PathValue("resource")))
+			return
+		}
+		res.serveGet(w, "", r.PathValue("name"))
+	})
+	mux.HandleFunc("GET /api/{resource}/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		res, ok := resources[r.PathValue("resource")]
+		if !ok {
+			writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: "resources"}, r.PathValue("resource")))
+			return
+		}
+		res.serveGet(w, r.PathValue("namespace"), r.PathValue("name"))
+	})
+
+	server := &http.Server{Addr: o.listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	fmt.Fprintf(log, "serving %d resources on http://%s\n", len(resources), o.listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveList answers a list from the cache, filtered by the namespace, label
+// selector and owner query parameters.
+func (res *cachedResource) serveList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	selector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	namespace := query.Get("namespace")
+
+	var objects []runtime.Object
+	if owner := query.Get("owner"); owner != "" {
+		indexed, err := res.informer.GetIndexer().ByIndex(ownerIndex, owner)
+		if err != nil {
+			writeStatus(w, apierrors.NewInternalError(err))
+			return
+		}
+		for _, obj := range indexed {
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				continue
+			}
+			if (namespace == "" || accessor.GetNamespace() == namespace) && selector.Matches(labels.Set(accessor.GetLabels())) {
+				objects = append(objects, obj.(runtime.Object))
+			}
+		}
+	} else if namespace != "" && res.namespaced {
+		// The namespace lister is answered from the built-in namespace index.
+		objects, err = res.lister.ByNamespace(namespace).List(selector)
+	} else {
+		objects, err = res.lister.List(selector)
+	}
+	if err != nil {
+		writeStatus(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		a, _ := cache.MetaNamespaceKeyFunc(objects[i])
+		b, _ := cache.MetaNamespaceKeyFunc(objects[j])
+		return a < b
+	})
+	if objects == nil {
+		objects = []runtime.Object{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"apiVersion": "v1", "kind": "List", "items": objects})
+}
+
+func (res *cachedResource) serveGet(w http.ResponseWriter, namespace, name string) {
+	var obj runtime.Object
+	var err error
+	if res.namespaced {
+		if namespace == "" {
+			writeStatus(w, apierrors.NewBadRequest(fmt.Sprintf("%s is namespaced, use /api/%s/<namespace>/<name>", res.resource, res.resource.Resource)))
+			return
+		}
+		obj, err = res.lister.ByNamespace(namespace).Get(name)
+	} else {
+		obj, err = res.lister.Get(name)
+	}
+	if err != nil {
+		writeStatus(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// indexByOwner is the index function of ownerIndex.
+func indexByOwner(obj any) ([]string, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]string, 0, len(accessor.GetOwnerReferences()))
+	for _, ref := range accessor.GetOwnerReferences() {
+		uids = append(uids, string(ref.UID))
+	}
+	return uids, nil
+}
+
+// writeStatus answers with the metav1.Status of err, like the API server.
+// Errors that carry no status are reported as internal errors.
+func writeStatus(w http.ResponseWriter, err error) {
+	status := apierrors.APIStatus(apierrors.NewInternalError(err))
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		status = apiStatus
+	}
+	s := status.Status()
+	s.Kind, s.APIVersion = "Status", metav1.SchemeGroupVersion.Version
+	writeJSON(w, int(s.Code), s)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// cascadePolicies maps the --cascade flag values to propagation policies.
+var cascadePolicies = map[string]metav1.DeletionPropagation{
+	"background": metav1.DeletePropagationBackground,
+	"foreground": metav1.DeletePropagationForeground,
+	"orphan":     metav1.DeletePropagationOrphan,
+}
+
+// deleteOptions holds the flags of the delete command.
+type deleteOptions struct {
+	factory     *kube.Factory
+	cascade     string
+	gracePeriod int64
+	wait        bool
+	timeout     time.Duration
+	selectors   selectorFlags
+	chunkSize   int64
+	output      string
+}
+
+func newDeleteCmd(f *kube.Factory) *cobra.Command {
+	o := &deleteOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "delete <resource> (<name>... | -l SELECTOR) | <resource>/<name>",
+		Short: "Delete objects with an explicit propagation policy",
+		Long: `Delete removes the objects and tells the garbage collector what to do with
+their dependents (the objects listing them in ownerReferences):
+
+  background  the object is deleted at once, dependents are removed afterwards
+  foreground  the object stays (with a deletionTimestamp) until every
+              dependent with blockOwnerDeletion has been deleted
+  orphan      dependents are kept and their ownerReferences are removed
+
+With --wait the command blocks until the objects are gone. In foreground mode
+this also means their blocking dependents are gone.
+
+Instead of names, -l/--selector and --field-selector delete every matching
+object.`,
+		Example: `  k8sctl delete deploy/web
+  k8sctl delete deploy web api --cascade=foreground --wait
+  k8sctl delete rs web-5d4c --cascade=orphan
+  k8sctl delete pods -l app=web --field-selector status.phase=Failed`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource, names := args[0], args[1:]
+			if len(args) == 1 && !o.selectors.isSet() {
+				r, name, err := splitResourceName(args)
+				if err != nil {
+					return err
+				}
+				resource, names = r, []string{name}
+			}
+			p, err := newObjectPrinter(cmd, o.output, printer.FormatName)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.OutOrStdout(), p, resource, names)
+		},
+	}
+	cmd.Flags().StringVar(&o.cascade, "cascade", "background", "Propagation policy for dependents: background, foreground or orphan")
+	cmd.Flags().Int64Var(&o.gracePeriod, "grace-period", -1, "Seconds given to the object to terminate gracefully, -1 uses the object default")
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the objects are gone")
+	cmd.Flags().DurationVar(&o.timeout, "timeout", 5*time.Minute, "How long to wait for the deletion with --wait")
+	addOutputFlag(cmd, &o.output, printer.FormatName)
+	o.selectors.addFlags(cmd)
+	addChunkSizeFlag(cmd, &o.chunkSize)
+
+	return cmd
+}
+
+// run deletes the objects. With -o name only the names of the deleted objects
+// are printed by p.
+func (o *deleteOptions) run(ctx context.Context, out io.Writer, p printer.Printer, resource string, names []string) error {
+	policy, ok := cascadePolicies[o.cascade]
+	if !ok {
+		return fmt.Errorf("unknown cascade %q, expected background, foreground or orphan", o.cascade)
+	}
+	if o.selectors.isSet() && len(names) > 0 {
+		return fmt.Errorf("names cannot be combined with a selector")
+	}
+	if err := o.selectors.validate(); err != nil {
+		return err
+	}
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.DeleteOptions{PropagationPolicy: &policy}
+	if o.gracePeriod >= 0 {
+		opts.GracePeriodSeconds = &o.gracePeriod
+	}
+
+	targets, err := o.targets(ctx, client, names)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		if p == nil {
+			fmt.Fprintln(out, "No resources found")
+		}
+		return nil
+	}
+
+	// The UIDs are remembered so that waiting is not fooled by an object
+	// recreated under the same name.
+	deleted := map[string]types.UID{}
+	names = names[:0]
+	for _, target := range targets {
+		name := target.GetName()
+		opts.Preconditions = &metav1.Preconditions{UID: ptr.To(target.GetUID())}
+		if err := client.Delete(ctx, name, opts); err != nil {
+			return err
+		}
+		deleted[name] = target.GetUID()
+		names = append(names, name)
+		if p != nil {
+			if err := p.PrintObjects([]runtime.Object{&target}); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(out, "%s/%s deleted (%s)\n", printer.KindGroup(mapping.GroupVersionKind), name, o.cascade)
+	}
+
+	if !o.wait {
+		return nil
+	}
+	for _, name := range names {
+		if err := waitForDeletion(ctx, client, name, deleted[name], o.timeout); err != nil {
+			return fmt.Errorf("waiting for %s/%s: %w", printer.KindGroup(mapping.GroupVersionKind), name, err)
+		}
+		if p == nil {
+			fmt.Fprintf(out, "%s/%s gone\n", printer.KindGroup(mapping.GroupVersionKind), name)
+		}
+	}
+	return nil
+}
+
+// targets returns the live objects to delete: the named ones, or every object
+// matching the selectors.
+func (o *deleteOptions) targets(ctx context.Context, client dynamic.ResourceInterface, names []string) ([]unstructured.Unstructured, error) {
+	var targets []unstructured.Unstructured
+	if len(names) == 0 {
+		opts := o.selectors.listOptions()
+		opts.Limit = o.chunkSize
+		err := listPages(ctx, client, opts, func(list *unstructured.UnstructuredList) error {
+			targets = append(targets, list.Items...)
+			return nil
+		})
+		return targets, err
+	}
+
+	for _, name := range names {
+		live, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, *live)
+	}
+	return targets, nil
+}
+
+// waitForDeletion polls until the object with the given UID no longer exists.
+// An object in foreground deletion stays visible, with the foregroundDeletion
+// finalizer, until the garbage collector removed its blocking dependents.
+func waitForDeletion(ctx context.Context, client dynamic.ResourceInterface, name string, uid types.UID, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		live, err := client.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return live.GetUID() != uid, nil
+	})
+}
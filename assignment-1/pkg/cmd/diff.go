@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/exec"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/diff"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/manifest"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// errDifferences is returned, as exit code 1, when diff found differences.
+// It is the result of the command rather than a failure and is not printed.
+var errDifferences = errors.New("differences found")
+
+// diffIgnored are the fields that differ between the live object and the
+// dry-run result without the manifest having changed anything, or that an
+// apply never sets.
+var diffIgnored = []string{
+	"metadata.managedFields",
+	"metadata.resourceVersion",
+	"metadata.generation",
+	"metadata.creationTimestamp",
+	"metadata.uid",
+	"status",
+}
+
+// diffOptions holds the flags of the diff command.
+type diffOptions struct {
+	factory        *kube.Factory
+	filenames      []string
+	fieldManager   string
+	forceConflicts bool
+}
+
+func newDiffCmd(f *kube.Factory) *cobra.Command {
+	o := &diffOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "diff -f FILENAME",
+		Short: "Show what applying manifests would change",
+		Long: `Diff sends every object of the manifests as a server-side apply with
+dryRun=All. The API server merges, defaults, validates and runs the admission
+webhooks exactly as for a real apply but persists nothing; the result is
+compared field by field with the live object.
+
+Like diff(1) the command exits with 0 when there are no differences, 1 when
+there are differences and 2 when the diff itself failed, e.g. because of an
+invalid manifest, a denied request or a rejected dry-run.`,
+		Example: `  k8sctl diff -f app.yaml
+  k8sctl diff -f manifests/ --field-manager=ci`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.NoArgs(cmd, args); err != nil {
+				return diffFailed(err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// -f is checked here rather than with MarkFlagRequired so that
+			// its absence also exits with 2.
+			if len(o.filenames) == 0 {
+				return diffFailed(fmt.Errorf("required flag \"filename\" not set"))
+			}
+			err := o.run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+			if err != nil && !IsDifferences(err) {
+				return diffFailed(err)
+			}
+			return err
+		},
+	}
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error { return diffFailed(err) })
+	cmd.Flags().StringSliceVarP(&o.filenames, "filename", "f", nil, "Manifest file, directory or - for stdin")
+	cmd.Flags().StringVar(&o.fieldManager, "field-manager", defaultFieldManager, "Name of the manager the dry-run apply is made as")
+	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, "Diff as if conflicting fields were taken over")
+
+	return cmd
+}
+
+// IsDifferences reports whether err is the exit code 1 of diff that stands
+// for differences found.
+func IsDifferences(err error) bool {
+	var exitErr exec.CodeExitError
+	return errors.As(err, &exitErr) && exitErr.Err == errDifferences
+}
+
+// diffFailed makes a failure of the diff itself exit with 2, so that it is
+// not mistaken for differences.
+func diffFailed(err error) error {
+	return exec.CodeExitError{Err: err, Code: 2}
+}
+
+func (o *diffOptions) run(ctx context.Context, in io.Reader, out io.Writer) error {
+	objects, err := manifest.ReadPaths(o.filenames, in)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	differ := false
+	for _, obj := range objects {
+		ref := printer.KindName(obj.Unstructured)
+		live, merged, err := o.dryRun(ctx, obj.Unstructured, namespace)
+		if err != nil {
+			return fmt.Errorf("%s (%s): %s", ref, obj.Source, describeApplyError(err))
+		}
+
+		var changes []diff.Change
+		if live == nil {
+			fmt.Fprintf(out, "%s (%s): new object\n", ref, obj.Source)
+			changes = diff.Compare(map[string]any{}, merged.Object, diffIgnored...)
+		} else {
+			changes = diff.Compare(live.Object, merged.Object, diffIgnored...)
+			if len(changes) == 0 {
+				continue
+			}
+			fmt.Fprintf(out, "%s (%s)\n", ref, obj.Source)
+		}
+		differ = true
+		for _, c := range changes {
+			fmt.Fprintf(out, "  %s\n", c)
+		}
+	}
+
+	if differ {
+		return exec.CodeExitError{Err: errDifferences, Code: 1}
+	}
+	return nil
+}
+
+// dryRun returns the live object, nil when it does not exist yet, and the
+// object as it would be after applying obj.
+func (o *diffOptions) dryRun(ctx context.Context, obj *unstructured.Unstructured, namespace string) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	if obj.GetNamespace() != "" {
+		namespace = obj.GetNamespace()
+	}
+	mapping, err := o.factory.MappingFor(obj.GroupVersionKind())
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	live, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	merged, err := client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: o.fieldManager,
+		Force:        &o.forceConflicts,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return live, merged, nil
+}
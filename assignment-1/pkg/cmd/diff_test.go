@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/util/exec"
+)
+
+func TestDiffExitCodes(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "positional argument", args: []string{"diff", "extra", "-f", missing}, wantErr: `unknown command "extra"`},
+		{name: "unknown flag", args: []string{"diff", "--bogus"}, wantErr: "unknown flag: --bogus"},
+		{name: "missing filename", args: []string{"diff"}, wantErr: `required flag "filename" not set`},
+		{name: "unreadable manifest", args: []string{"diff", "-f", missing}, wantErr: "no such file or directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRootCmd()
+			root.SetArgs(tt.args)
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+
+			err := root.Execute()
+			var exitErr exec.CodeExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != 2 {
+				t.Fatalf("Execute() error = %v, want exit code 2", err)
+			}
+			if IsDifferences(err) {
+				t.Errorf("IsDifferences(%v) = true, want false", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// mirrorPodAnnotation marks the API copies of static pods run by the kubelet
+// from its manifest directory. They cannot be evicted through the API.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// evictionRetryInterval is how long drain waits before retrying an eviction
+// refused by a PodDisruptionBudget.
+const evictionRetryInterval = 5 * time.Second
+
+// drainOptions holds the flags of the drain command.
+type drainOptions struct {
+	factory            *kube.Factory
+	gracePeriod        int64
+	timeout            time.Duration
+	ignoreDaemonSets   bool
+	deleteEmptyDirData bool
+	force              bool
+}
+
+func newCordonCmd(f *kube.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cordon <node>",
+		Short:   "Mark a node unschedulable",
+		Example: `  k8sctl cordon worker-1`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setUnschedulable(cmd.Context(), f, cmd.OutOrStdout(), args[0], true)
+		},
+	}
+}
+
+func newUncordonCmd(f *kube.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "uncordon <node>",
+		Short:   "Mark a node schedulable again",
+		Example: `  k8sctl uncordon worker-1`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setUnschedulable(cmd.Context(), f, cmd.OutOrStdout(), args[0], false)
+		},
+	}
+}
+
+func newDrainCmd(f *kube.Factory) *cobra.Command {
+	o := &drainOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "drain <node>",
+		Short: "Cordon a node and evict its pods",
+		Long: `Drain cordons the node and evicts its pods through the eviction API
+(pods/eviction), which honours PodDisruptionBudgets: an eviction that would
+violate a budget is refused with 429 Too Many Requests and retried every few
+seconds until --timeout, giving the controllers time to bring replacement
+pods up elsewhere.
+
+Some pods are skipped or block the drain:
+
+  mirror pods                  skipped, static pods belong to the kubelet
+  DaemonSet pods               blocking, skipped with --ignore-daemonsets
+                               (the DaemonSet controller ignores cordons)
+  pods without a controller    blocking, evicted with --force; nothing will
+                               recreate them
+  pods with emptyDir volumes   blocking, evicted with --delete-emptydir-data;
+                               the data is lost
+
+The command returns once every evicted pod is gone.`,
+		Example: `  k8sctl drain worker-1 --ignore-daemonsets
+  k8sctl drain worker-1 --ignore-daemonsets --delete-emptydir-data --timeout=10m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+	cmd.Flags().Int64Var(&o.gracePeriod, "grace-period", -1, "Seconds given to each pod to terminate gracefully, -1 uses the pod default")
+	cmd.Flags().DurationVar(&o.timeout, "timeout", 5*time.Minute, "How long to keep evicting and waiting before giving up")
+	cmd.Flags().BoolVar(&o.ignoreDaemonSets, "ignore-daemonsets", false, "Skip pods managed by a DaemonSet")
+	cmd.Flags().BoolVar(&o.deleteEmptyDirData, "delete-emptydir-data", false, "Evict pods using emptyDir volumes, deleting their data")
+	cmd.Flags().BoolVar(&o.force, "force", false, "Evict pods not managed by a controller")
+
+	return cmd
+}
+
+// setUnschedulable cordons or uncordons the node with a merge patch of
+// spec.unschedulable.
+func setUnschedulable(ctx context.Context, f *kube.Factory, out io.Writer, name string, unschedulable bool) error {
+	cs, err := f.ClientSet()
+	if err != nil {
+		return err
+	}
+	verb := "cordoned"
+	if !unschedulable {
+		verb = "uncordoned"
+	}
+
+	node, err := cs.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		fmt.Fprintf(out, "node/%s already %s\n", name, verb)
+		return nil
+	}
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	if _, err := cs.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{FieldManager: defaultFieldManager}); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "node/%s %s\n", name, verb)
+	return nil
+}
+
+func (o *drainOptions) run(ctx context.Context, out, errOut io.Writer, node string) error {
+	if err := setUnschedulable(ctx, o.factory, out, node, true); err != nil {
+		return err
+	}
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	pods, err := cs.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return err
+	}
+	evict, err := o.filterPods(errOut, pods.Items)
+	if err != nil {
+		return err
+	}
+
+	// Pods are evicted concurrently: a budget blocking one pod must not hold
+	// up the others.
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []error
+	)
+	for _, pod := range evict {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := o.evictAndWait(ctx, cs, errOut, pod)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, fmt.Errorf("pod %s/%s: %w", pod.Namespace, pod.Name, err))
+				return
+			}
+			fmt.Fprintf(out, "pod/%s evicted\n", pod.Name)
+		}()
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		for _, err := range failed {
+			fmt.Fprintln(errOut, err)
+		}
+		return fmt.Errorf("%d of %d pods could not be evicted from node %s, it stays cordoned", len(failed), len(evict), node)
+	}
+	fmt.Fprintf(out, "node/%s drained\n", node)
+	return nil
+}
+
+// filterPods returns the pods to evict. Pods that must not be evicted without
+// a flag make the whole drain fail before anything is evicted.
+func (o *drainOptions) filterPods(errOut io.Writer, pods []corev1.Pod) ([]corev1.Pod, error) {
+	var evict []corev1.Pod
+	var blocking []string
+	for _, pod := range pods {
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		controller := metav1.GetControllerOf(&pod)
+		if controller != nil && controller.Kind == "DaemonSet" {
+			if !o.ignoreDaemonSets {
+				blocking = append(blocking, fmt.Sprintf("%s/%s is managed by a DaemonSet (use --ignore-daemonsets)", pod.Namespace, pod.Name))
+			} else {
+				fmt.Fprintf(errOut, "ignoring DaemonSet-managed pod %s/%s\n", pod.Namespace, pod.Name)
+			}
+			continue
+		}
+		// Finished pods have nothing left to disrupt.
+		finished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+		if controller == nil && !o.force && !finished {
+			blocking = append(blocking, fmt.Sprintf("%s/%s is not managed by a controller (use --force)", pod.Namespace, pod.Name))
+			continue
+		}
+		if hasEmptyDir(&pod) && !o.deleteEmptyDirData && !finished {
+			blocking = append(blocking, fmt.Sprintf("%s/%s uses emptyDir volumes (use --delete-emptydir-data)", pod.Namespace, pod.Name))
+			continue
+		}
+		evict = append(evict, pod)
+	}
+	if len(blocking) > 0 {
+		return nil, fmt.Errorf("cannot drain, these pods block it:\n  %s", strings.Join(blocking, "\n  "))
+	}
+	return evict, nil
+}
+
+// evictAndWait evicts the pod, retrying while a PodDisruptionBudget refuses
+// it, then waits until the pod is gone.
+func (o *drainOptions) evictAndWait(ctx context.Context, cs kubernetes.Interface, errOut io.Writer, pod corev1.Pod) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		// The UID precondition avoids evicting a new pod reusing the name,
+		// e.g. a recreated StatefulSet pod.
+		DeleteOptions: &metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions(string(pod.UID))},
+	}
+	if o.gracePeriod >= 0 {
+		eviction.DeleteOptions.GracePeriodSeconds = &o.gracePeriod
+	}
+
+	err := wait.PollUntilContextCancel(ctx, evictionRetryInterval, true, func(ctx context.Context) (bool, error) {
+		err := cs.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil, apierrors.IsNotFound(err), apierrors.IsConflict(err):
+			// Gone already, or replaced by a pod with another UID.
+			return true, nil
+		case apierrors.IsTooManyRequests(err):
+			fmt.Fprintf(errOut, "evicting pod %s/%s refused by a disruption budget, retrying in %s\n", pod.Namespace, pod.Name, evictionRetryInterval)
+			return false, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("eviction still refused after --timeout")
+		}
+		return err
+	}
+
+	err = wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		live, err := cs.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return live.UID != pod.UID, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("evicted but not terminated within --timeout")
+	}
+	return err
+}
+
+func hasEmptyDir(pod *corev1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
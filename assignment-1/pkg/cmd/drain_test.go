@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestFilterPods(t *testing.T) {
+	pod := func(name, controllerKind string, phase corev1.PodPhase, emptyDir bool) corev1.Pod {
+		p := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if controllerKind != "" {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: controllerKind, Name: "owner", Controller: ptr.To(true)}}
+		}
+		if emptyDir {
+			p.Spec.Volumes = []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+		}
+		return p
+	}
+	mirror := pod("kube-apiserver", "", corev1.PodRunning, false)
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "abc"}
+
+	web := pod("web", "ReplicaSet", corev1.PodRunning, false)
+	agent := pod("agent", "DaemonSet", corev1.PodRunning, false)
+	bare := pod("bare", "", corev1.PodRunning, false)
+	cache := pod("cache", "StatefulSet", corev1.PodRunning, true)
+	job := pod("job", "", corev1.PodSucceeded, true)
+
+	tests := []struct {
+		name       string
+		opts       drainOptions
+		pods       []corev1.Pod
+		want       []string
+		wantErr    []string
+		wantErrOut string
+	}{
+		{
+			name: "controlled pods are evicted, mirror pods skipped",
+			pods: []corev1.Pod{mirror, web},
+			want: []string{"web"},
+		},
+		{
+			name: "finished pods are evicted without --force",
+			pods: []corev1.Pod{job},
+			want: []string{"job"},
+		},
+		{
+			name: "blocking pods are all reported",
+			pods: []corev1.Pod{web, agent, bare, cache},
+			wantErr: []string{
+				"default/agent is managed by a DaemonSet (use --ignore-daemonsets)",
+				"default/bare is not managed by a controller (use --force)",
+				"default/cache uses emptyDir volumes (use --delete-emptydir-data)",
+			},
+		},
+		{
+			name:       "ignore DaemonSets",
+			opts:       drainOptions{ignoreDaemonSets: true},
+			pods:       []corev1.Pod{web, agent},
+			want:       []string{"web"},
+			wantErrOut: "ignoring DaemonSet-managed pod default/agent\n",
+		},
+		{
+			name: "force evicts unmanaged pods",
+			opts: drainOptions{force: true},
+			pods: []corev1.Pod{bare},
+			want: []string{"bare"},
+		},
+		{
+			name: "delete emptyDir data",
+			opts: drainOptions{deleteEmptyDirData: true},
+			pods: []corev1.Pod{cache},
+			want: []string{"cache"},
+		},
+		{
+			name:    "force does not allow emptyDir data",
+			opts:    drainOptions{force: true},
+			pods:    []corev1.Pod{bare, cache},
+			wantErr: []string{"default/cache uses emptyDir volumes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errOut bytes.Buffer
+			evict, err := tt.opts.filterPods(&errOut, tt.pods)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("filterPods() succeeded, want an error")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("filterPods() error = %v, want it to contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterPods() error = %v", err)
+			}
+			var names []string
+			for _, p := range evict {
+				names = append(names, p.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("filterPods() = %q, want %q", names, tt.want)
+			}
+			if got := errOut.String(); got != tt.wantErrOut {
+				t.Errorf("filterPods() wrote %q, want %q", got, tt.wantErrOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// newDynCmd groups the commands built on the dynamic client. Unlike the typed
+// clientset these work with any resource served by the cluster, CRDs included.
+func newDynCmd(f *kube.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dyn",
+		Short: "Work with arbitrary resources through the dynamic client",
+	}
+	cmd.AddCommand(newDynGetCmd(f))
+	return cmd
+}
+
+// dynGetOptions holds the flags of the dyn get command.
+type dynGetOptions struct {
+	factory       *kube.Factory
+	allNamespaces bool
+	chunkSize     int64
+	selectors     selectorFlags
+	output        string
+	fanOut        fanOutFlags
+}
+
+func newDynGetCmd(f *kube.Factory) *cobra.Command {
+	o := &dynGetOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "get <group/version/resource|resource|category> [name]",
+		Short: "Get objects of any resource type using the dynamic client",
+		Example: `  k8sctl dyn get apps/v1/deployments
+  k8sctl dyn get v1/configmaps kube-root-ca.crt
+  k8sctl dyn get cronjobs.batch -A
+  k8sctl dyn get all`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 2 {
+				name = args[1]
+			}
+			return o.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], name)
+		},
+	}
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "List objects across all namespaces")
+	addChunkSizeFlag(cmd, &o.chunkSize)
+	addOutputFlag(cmd, &o.output, outputFormatsAll)
+	o.selectors.addFlags(cmd)
+	o.fanOut.addFlags(cmd)
+
+	return cmd
+}
+
+func (o *dynGetOptions) run(ctx context.Context, out, errOut io.Writer, resource, name string) error {
+	if name != "" && o.selectors.isSet() {
+		return fmt.Errorf("a name cannot be combined with a selector")
+	}
+	if name != "" && o.allNamespaces {
+		return fmt.Errorf("a resource cannot be retrieved by name across all namespaces")
+	}
+	if err := o.selectors.validate(); err != nil {
+		return err
+	}
+
+	printOpts := printer.Options{
+		Format:        o.output,
+		Columns:       []printer.Column{{Header: "AGE", Value: objectAge}},
+		WithNamespace: o.allNamespaces,
+		SingleObject:  name != "",
+	}
+	fetch := func(ctx context.Context, f *kube.Factory, emit func([]runtime.Object) error) error {
+		mappings, err := o.resolve(f, resource, name)
+		if err != nil {
+			return err
+		}
+		return o.fetch(ctx, f, mappings, name, emit)
+	}
+
+	// The resource is resolved in every cluster on its own, since they may
+	// serve different CRDs. Names are therefore always prefixed with the kind.
+	if o.fanOut.enabled() {
+		printOpts.WithKind = true
+		return o.fanOut.fanOut(ctx, o.factory, printOpts, out, errOut, fetch)
+	}
+
+	mappings, err := o.resolve(o.factory, resource, name)
+	if err != nil {
+		return err
+	}
+	// Objects are prefixed with their kind when more than one resource type
+	// is printed, e.g. for the "all" category.
+	printOpts.WithKind = len(mappings) > 1
+	p, err := printer.New(out, printOpts)
+	if err != nil {
+		return err
+	}
+	if err := o.fetch(ctx, o.factory, mappings, name, p.PrintObjects); err != nil {
+		return err
+	}
+	if err := p.Flush(); err != nil {
+		return err
+	}
+
+	if p.Count() == 0 && isTableFormat(o.output) {
+		fmt.Fprintln(out, "No resources found")
+	}
+	return nil
+}
+
+// resolve maps the resource argument through the discovery of f.
+func (o *dynGetOptions) resolve(f *kube.Factory, resource, name string) ([]*meta.RESTMapping, error) {
+	mappings, err := f.ResolveResources(resource)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && len(mappings) > 1 {
+		return nil, fmt.Errorf("a name cannot be combined with the category %q", resource)
+	}
+	return mappings, nil
+}
+
+// fetch reads the objects of every mapping from the cluster of f and hands
+// them to emit page by page.
+func (o *dynGetOptions) fetch(ctx context.Context, f *kube.Factory, mappings []*meta.RESTMapping, name string, emit func([]runtime.Object) error) error {
+	namespace, err := resolveNamespace(f, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range mappings {
+		err := o.get(ctx, f, mapping, namespace, name, func(items []unstructured.Unstructured) error {
+			objs := make([]runtime.Object, len(items))
+			for i := range items {
+				objs[i] = &items[i]
+			}
+			return emit(objs)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// get fetches a single named object or lists all objects of the mapping,
+// calling fn once per page of results.
+func (o *dynGetOptions) get(ctx context.Context, f *kube.Factory, mapping *meta.RESTMapping, namespace, name string, fn func([]unstructured.Unstructured) error) error {
+	client, err := f.ResourceClient(mapping, namespace)
+	if err != nil {
+		return err
+	}
+
+	if name != "" {
+		obj, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		return fn([]unstructured.Unstructured{*obj})
+	}
+
+	opts := o.selectors.listOptions()
+	opts.Limit = o.chunkSize
+	return listPages(ctx, client, opts, func(list *unstructured.UnstructuredList) error {
+		return fn(list.Items)
+	})
+}
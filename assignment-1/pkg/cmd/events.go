@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// Event APIs selectable with --api.
+const (
+	eventsAPIEvents = "events"
+	eventsAPICore   = "core"
+)
+
+// eventColumns are the table columns of both event APIs; the names of events
+// are generated and not shown.
+var eventColumns = []printer.Column{
+	{Header: "LAST SEEN", Value: func(obj runtime.Object) string { return age(eventInfoFor(obj).lastSeen) }},
+	{Header: "TYPE", Value: func(obj runtime.Object) string { return eventInfoFor(obj).eventType }},
+	{Header: "REASON", Value: func(obj runtime.Object) string { return eventInfoFor(obj).reason }},
+	{Header: "OBJECT", Value: func(obj runtime.Object) string { return eventInfoFor(obj).object }},
+	{Header: "MESSAGE", Value: func(obj runtime.Object) string { return eventInfoFor(obj).message }},
+	{Header: "SOURCE", Wide: true, Value: func(obj runtime.Object) string { return orNone(eventInfoFor(obj).source) }},
+	{Header: "FIRST SEEN", Wide: true, Value: func(obj runtime.Object) string { return age(eventInfoFor(obj).firstSeen) }},
+	{Header: "COUNT", Wide: true, Value: func(obj runtime.Object) string { return fmt.Sprint(eventInfoFor(obj).count) }},
+}
+
+// eventsOptions holds the flags of the events command.
+type eventsOptions struct {
+	factory       *kube.Factory
+	allNamespaces bool
+	api           string
+	forObject     string
+	warningsOnly  bool
+	watch         bool
+	chunkSize     int64
+	output        string
+}
+
+func newEventsCmd(f *kube.Factory) *cobra.Command {
+	o := &eventsOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "events [--for <resource>/<name>]",
+		Short: "List and watch events",
+		Long: `Events lists events sorted by the time they were last seen, the most recent
+last. They are read from the events.k8s.io/v1 API or, with --api=core, from
+the core v1 API. Both serve the same objects in a different shape.
+
+--for only shows the events about one object, --warnings-only only the
+warnings. With -w new and updated events are
+printed as they happen after the current ones.`,
+		Example: `  k8sctl events
+  k8sctl events --for deploy/web --warnings-only
+  k8sctl events -A -w --api=core`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "List events across all namespaces")
+	cmd.Flags().StringVar(&o.api, "api", eventsAPIEvents, "Events API to read: events (events.k8s.io/v1) or core (v1)")
+	cmd.Flags().StringVar(&o.forObject, "for", "", "Only show events about this object, as <resource>/<name>")
+	cmd.Flags().BoolVar(&o.warningsOnly, "warnings-only", false, "Only show events of type Warning")
+	cmd.Flags().BoolVarP(&o.watch, "watch", "w", false, "After listing, watch for new and updated events")
+	addChunkSizeFlag(cmd, &o.chunkSize)
+	addOutputFlag(cmd, &o.output, outputFormatsAll)
+
+	return cmd
+}
+
+func (o *eventsOptions) run(ctx context.Context, out io.Writer) error {
+	if o.api != eventsAPIEvents && o.api != eventsAPICore {
+		return fmt.Errorf("unknown events API %q, expected events or core", o.api)
+	}
+	// JSON and YAML are printed as one List at the end, which never comes.
+	if o.watch && (o.output == printer.FormatJSON || o.output == printer.FormatYAML) {
+		return fmt.Errorf("output format %q cannot be used with --watch", o.output)
+	}
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace, err := resolveNamespace(o.factory, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+	selector, clusterScoped, err := o.fieldSelector()
+	if err != nil {
+		return err
+	}
+	// Events about cluster scoped objects such as nodes are usually recorded
+	// in the default namespace; search all of them.
+	if clusterScoped {
+		namespace = metav1.NamespaceAll
+	}
+
+	p, err := printer.New(out, printer.Options{
+		Format:        o.output,
+		Columns:       eventColumns,
+		WithNamespace: namespace == metav1.NamespaceAll,
+		WithoutName:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.ListOptions{FieldSelector: selector, Limit: o.chunkSize}
+	events, resourceVersion, err := o.list(ctx, cs, namespace, opts)
+	if err != nil {
+		return err
+	}
+	sortEvents(events)
+	if err := p.PrintObjects(events); err != nil {
+		return err
+	}
+	if !o.watch {
+		if err := p.Flush(); err != nil {
+			return err
+		}
+		if p.Count() == 0 && isTableFormat(o.output) {
+			fmt.Fprintln(out, "No events found")
+		}
+		return nil
+	}
+
+	// The retry watcher resumes from the last seen resourceVersion when the
+	// API server closes the watch, so long running watches do not miss events.
+	w, err := watchtools.NewRetryWatcherWithContext(ctx, resourceVersion, &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return o.watchEvents(ctx, cs, namespace, options)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return p.Flush()
+		case <-w.Done():
+			return p.Flush()
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return p.Flush()
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				if err := p.PrintObjects([]runtime.Object{setEventKind(ev.Object)}); err != nil {
+					return err
+				}
+			case watch.Error:
+				return fmt.Errorf("watching events: %w", apierrors.FromObject(ev.Object))
+			}
+		}
+	}
+}
+
+// fieldSelector builds the server side filter for --for and
+// --warnings-only. It also reports whether --for names a cluster scoped
+// object.
+func (o *eventsOptions) fieldSelector() (string, bool, error) {
+	if o.forObject == "" {
+		return eventSelector(o.api, o.warningsOnly, "", ""), false, nil
+	}
+	resource, name, err := splitResourceName([]string{o.forObject})
+	if err != nil {
+		return "", false, fmt.Errorf("invalid --for: %w", err)
+	}
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return "", false, err
+	}
+	clusterScoped := mapping.Scope.Name() == meta.RESTScopeNameRoot
+	return eventSelector(o.api, o.warningsOnly, mapping.GroupVersionKind.Kind, name), clusterScoped, nil
+}
+
+// eventSelector returns the field selector of the events about the object of
+// the given kind and name, if any, and only of the warnings with
+// warningsOnly. The two APIs name the object field differently.
+func eventSelector(api string, warningsOnly bool, kind, name string) string {
+	set := fields.Set{}
+	prefix := "regarding."
+	if api == eventsAPICore {
+		prefix = "involvedObject."
+	}
+	if warningsOnly {
+		set["type"] = corev1.EventTypeWarning
+	}
+	if kind != "" {
+		set[prefix+"kind"] = kind
+		set[prefix+"name"] = name
+	}
+	return fields.SelectorFromSet(set).String()
+}
+
+// list reads all matching events page by page. Sorting needs all of them, so
+// the pages are collected instead of printed as they arrive. The returned
+// resourceVersion is where a watch continues.
+func (o *eventsOptions) list(ctx context.Context, cs kubernetes.Interface, namespace string, opts metav1.ListOptions) ([]runtime.Object, string, error) {
+	var objects []runtime.Object
+	for {
+		var listMeta metav1.ListMeta
+		switch o.api {
+		case eventsAPICore:
+			list, err := cs.CoreV1().Events(namespace).List(ctx, opts)
+			if err != nil {
+				return nil, "", pagingError(err)
+			}
+			for i := range list.Items {
+				objects = append(objects, setEventKind(&list.Items[i]))
+			}
+			listMeta = list.ListMeta
+		default:
+			list, err := cs.EventsV1().Events(namespace).List(ctx, opts)
+			if err != nil {
+				return nil, "", pagingError(err)
+			}
+			for i := range list.Items {
+				objects = append(objects, setEventKind(&list.Items[i]))
+			}
+			listMeta = list.ListMeta
+		}
+		if listMeta.Continue == "" {
+			return objects, listMeta.ResourceVersion, nil
+		}
+		opts.Continue = listMeta.Continue
+	}
+}
+
+func (o *eventsOptions) watchEvents(ctx context.Context, cs kubernetes.Interface, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	if o.api == eventsAPICore {
+		return cs.CoreV1().Events(namespace).Watch(ctx, opts)
+	}
+	return cs.EventsV1().Events(namespace).Watch(ctx, opts)
+}
+
+// setEventKind sets apiVersion/kind, which typed objects decoded from lists
+// and watches lack, so that the JSON, YAML and name output is complete.
+func setEventKind(obj runtime.Object) runtime.Object {
+	switch e := obj.(type) {
+	case *corev1.Event:
+		e.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Event"))
+	case *eventsv1.Event:
+		e.SetGroupVersionKind(eventsv1.SchemeGroupVersion.WithKind("Event"))
+	}
+	return obj
+}
+
+// eventInfo is the common view of the two event types used by the columns.
+type eventInfo struct {
+	lastSeen, firstSeen metav1.Time
+	eventType, reason   string
+	object, message     string
+	source              string
+	count               int32
+}
+
+// eventInfoFor extracts the columns of a core or events.k8s.io event. Which
+// timestamps and counters are set depends on the API version the recording
+// client used, so each falls back to the next best one.
+func eventInfoFor(obj runtime.Object) eventInfo {
+	switch e := obj.(type) {
+	case *corev1.Event:
+		info := eventInfo{
+			eventType: e.Type,
+			reason:    e.Reason,
+			object:    strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name,
+			message:   strings.TrimSpace(e.Message),
+			source:    firstNonEmpty(e.ReportingController, e.Source.Component),
+			count:     e.Count,
+		}
+		info.lastSeen = firstTime(e.LastTimestamp, seriesTime(e.Series), metav1.Time(e.EventTime), e.CreationTimestamp)
+		info.firstSeen = firstTime(e.FirstTimestamp, metav1.Time(e.EventTime), e.CreationTimestamp)
+		if e.Series != nil {
+			info.count = e.Series.Count
+		}
+		info.count = max(info.count, 1)
+		return info
+	case *eventsv1.Event:
+		info := eventInfo{
+			eventType: e.Type,
+			reason:    e.Reason,
+			object:    strings.ToLower(e.Regarding.Kind) + "/" + e.Regarding.Name,
+			message:   strings.TrimSpace(e.Note),
+			source:    firstNonEmpty(e.ReportingController, e.DeprecatedSource.Component),
+			count:     e.DeprecatedCount,
+		}
+		var series metav1.Time
+		if e.Series != nil {
+			series = metav1.Time(e.Series.LastObservedTime)
+			info.count = e.Series.Count
+		}
+		info.lastSeen = firstTime(series, e.DeprecatedLastTimestamp, metav1.Time(e.EventTime), e.CreationTimestamp)
+		info.firstSeen = firstTime(e.DeprecatedFirstTimestamp, metav1.Time(e.EventTime), e.CreationTimestamp)
+		info.count = max(info.count, 1)
+		return info
+	}
+	return eventInfo{}
+}
+
+// sortEvents orders events by the time they were last seen, oldest first.
+func sortEvents(events []runtime.Object) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventInfoFor(events[i]).lastSeen.Time.Before(eventInfoFor(events[j]).lastSeen.Time)
+	})
+}
+
+func seriesTime(series *corev1.EventSeries) metav1.Time {
+	if series == nil {
+		return metav1.Time{}
+	}
+	return metav1.Time(series.LastObservedTime)
+}
+
+// firstTime returns the first of times that is set.
+func firstTime(times ...metav1.Time) metav1.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return metav1.Time{}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestEventSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		api          string
+		warningsOnly bool
+		kind, object string
+		want         string
+	}{
+		{name: "everything", api: eventsAPIEvents, want: ""},
+		{name: "warnings only", api: eventsAPIEvents, warningsOnly: true, want: "type=Warning"},
+		{name: "for object", api: eventsAPIEvents, kind: "Deployment", object: "web", want: "regarding.kind=Deployment,regarding.name=web"},
+		{name: "for object with core API", api: eventsAPICore, kind: "Deployment", object: "web", want: "involvedObject.kind=Deployment,involvedObject.name=web"},
+		{name: "warnings for object", api: eventsAPICore, warningsOnly: true, kind: "Node", object: "worker-1", want: "involvedObject.kind=Node,involvedObject.name=worker-1,type=Warning"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The terms of a selector built from a set come in map order.
+			got := eventSelector(tt.api, tt.warningsOnly, tt.kind, tt.object)
+			if terms(got) != terms(tt.want) {
+				t.Errorf("eventSelector() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func terms(selector string) string {
+	parts := strings.Split(selector, ",")
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func TestSortEvents(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time { return metav1.NewTime(base.Add(time.Duration(minutes) * time.Minute)) }
+	microAt := func(minutes int) metav1.MicroTime { return metav1.NewMicroTime(at(minutes).Time) }
+	meta := func(name string, created metav1.Time) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, CreationTimestamp: created}
+	}
+
+	events := []runtime.Object{
+		// Core events are last seen at lastTimestamp, then at the series,
+		// eventTime and creation time.
+		&corev1.Event{ObjectMeta: meta("core-last", at(0)), FirstTimestamp: at(0), LastTimestamp: at(9)},
+		&corev1.Event{ObjectMeta: meta("core-series", at(0)), EventTime: microAt(1), Series: &corev1.EventSeries{Count: 3, LastObservedTime: microAt(5)}},
+		&corev1.Event{ObjectMeta: meta("core-event-time", at(0)), EventTime: microAt(2)},
+		// events.k8s.io events are last seen at the series, then at
+		// deprecatedLastTimestamp, eventTime and creation time.
+		&eventsv1.Event{ObjectMeta: meta("events-series", at(0)), EventTime: microAt(0), DeprecatedLastTimestamp: at(1), Series: &eventsv1.EventSeries{Count: 2, LastObservedTime: microAt(7)}},
+		&eventsv1.Event{ObjectMeta: meta("events-deprecated", at(0)), EventTime: microAt(0), DeprecatedLastTimestamp: at(3)},
+		&eventsv1.Event{ObjectMeta: meta("events-event-time", at(0)), EventTime: microAt(4)},
+		&eventsv1.Event{ObjectMeta: meta("events-created", at(6))},
+	}
+	sortEvents(events)
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.(metav1.Object).GetName())
+	}
+	want := []string{
+		"core-event-time",   // 2
+		"events-deprecated", // 3
+		"events-event-time", // 4
+		"core-series",       // 5
+		"events-created",    // 6
+		"events-series",     // 7
+		"core-last",         // 9
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortEvents() order = %q, want %q", got, want)
+	}
+}
+
+func TestEventInfoFor(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	eventTime := metav1.NewMicroTime(created.Add(time.Minute))
+
+	tests := []struct {
+		name string
+		obj  runtime.Object
+		want eventInfo
+	}{
+		{
+			name: "core event",
+			obj: &corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{CreationTimestamp: created},
+				Type:           corev1.EventTypeWarning,
+				Reason:         "BackOff",
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web"},
+				Message:        "Back-off restarting failed container\n",
+				Source:         corev1.EventSource{Component: "kubelet"},
+				Count:          4,
+			},
+			want: eventInfo{
+				lastSeen: created, firstSeen: created,
+				eventType: "Warning", reason: "BackOff", object: "pod/web",
+				message: "Back-off restarting failed container", source: "kubelet", count: 4,
+			},
+		},
+		{
+			name: "events.k8s.io event without series",
+			obj: &eventsv1.Event{
+				ObjectMeta:          metav1.ObjectMeta{CreationTimestamp: created},
+				EventTime:           eventTime,
+				Type:                corev1.EventTypeNormal,
+				Reason:              "ScalingReplicaSet",
+				Regarding:           corev1.ObjectReference{Kind: "Deployment", Name: "web"},
+				Note:                "Scaled up replica set web-5d4c to 3",
+				ReportingController: "deployment-controller",
+			},
+			want: eventInfo{
+				lastSeen: metav1.Time(eventTime), firstSeen: metav1.Time(eventTime),
+				eventType: "Normal", reason: "ScalingReplicaSet", object: "deployment/web",
+				message: "Scaled up replica set web-5d4c to 3", source: "deployment-controller", count: 1,
+			},
+		},
+		{
+			name: "events.k8s.io event with series",
+			obj: &eventsv1.Event{
+				ObjectMeta:       metav1.ObjectMeta{CreationTimestamp: created},
+				EventTime:        eventTime,
+				Regarding:        corev1.ObjectReference{Kind: "Node", Name: "worker-1"},
+				Series:           &eventsv1.EventSeries{Count: 12, LastObservedTime: metav1.NewMicroTime(created.Add(time.Hour))},
+				DeprecatedSource: corev1.EventSource{Component: "kubelet"},
+			},
+			want: eventInfo{
+				lastSeen: metav1.NewTime(created.Add(time.Hour)), firstSeen: metav1.Time(eventTime),
+				object: "node/worker-1", source: "kubelet", count: 12,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventInfoFor(tt.obj)
+			if !got.lastSeen.Equal(&tt.want.lastSeen) || !got.firstSeen.Equal(&tt.want.firstSeen) {
+				t.Errorf("eventInfoFor() seen = %v..%v, want %v..%v", got.firstSeen, got.lastSeen, tt.want.firstSeen, tt.want.lastSeen)
+			}
+			got.lastSeen, got.firstSeen = tt.want.lastSeen, tt.want.firstSeen
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eventInfoFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
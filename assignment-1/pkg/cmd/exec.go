@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// defaultContainerAnnotation selects the container used when -c is omitted,
+// the same annotation kubectl honours.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// execOptions holds the flags of the exec command.
+type execOptions struct {
+	factory   *kube.Factory
+	container string
+	stdin     bool
+	tty       bool
+}
+
+func newExecCmd(f *kube.Factory) *cobra.Command {
+	o := &execOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "exec <pod> [-c container] -- COMMAND [args...]",
+		Short: "Run a command in a container",
+		Long: `Exec runs a command in a container of a running pod. The pods/exec
+subresource is upgraded to a SPDY connection carrying one stream each for
+stdin, stdout, stderr, errors and (with --tty) terminal resize events.`,
+		Example: `  k8sctl exec web-5d4c -- ls /
+  k8sctl exec web-5d4c -c sidecar -- env
+  k8sctl exec -it web-5d4c -- sh`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.ArgsLenAtDash() != 1 {
+				return fmt.Errorf("expected exactly one pod name followed by -- and the command")
+			}
+			return o.run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], args[1:])
+		},
+	}
+	cmd.Flags().StringVarP(&o.container, "container", "c", "", "Container name, defaults to the default or first container of the pod")
+	cmd.Flags().BoolVarP(&o.stdin, "stdin", "i", false, "Pass stdin to the container")
+	cmd.Flags().BoolVarP(&o.tty, "tty", "t", false, "Allocate a TTY, stdin is put in raw mode")
+
+	return cmd
+}
+
+func (o *execOptions) run(ctx context.Context, in io.Reader, out, errOut io.Writer, podName string, command []string) error {
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return fmt.Errorf("cannot exec into a container of a completed pod, phase is %s", pod.Status.Phase)
+	}
+	container, err := selectContainer(pod, o.container, errOut)
+	if err != nil {
+		return err
+	}
+
+	// A TTY merges stderr into stdout on the server side.
+	req := cs.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     o.stdin,
+			Stdout:    true,
+			Stderr:    !o.tty,
+			TTY:       o.tty,
+		}, scheme.ParameterCodec)
+
+	cfg, err := o.factory.RESTConfig()
+	if err != nil {
+		return err
+	}
+	executor, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	streamOpts := remotecommand.StreamOptions{Stdout: out, Stderr: errOut, Tty: o.tty}
+	if o.stdin {
+		streamOpts.Stdin = in
+	}
+	if o.tty {
+		stdinFile, ok := in.(*os.File)
+		if !ok || !term.IsTerminal(int(stdinFile.Fd())) {
+			return fmt.Errorf("--tty requires stdin to be a terminal")
+		}
+		// Raw mode hands every key press, Ctrl-C included, to the remote
+		// process instead of interpreting it locally.
+		state, err := term.MakeRaw(int(stdinFile.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(stdinFile.Fd()), state) //nolint:errcheck
+
+		sizes := newTerminalSizeQueue(ctx, int(stdinFile.Fd()))
+		streamOpts.TerminalSizeQueue = sizes
+		streamOpts.Stderr = nil
+	}
+
+	return executor.StreamWithContext(ctx, streamOpts)
+}
+
+// selectContainer returns the container to use: the requested one, the one
+// named by the default-container annotation or the first one.
+func selectContainer(pod *corev1.Pod, requested string, errOut io.Writer) (string, error) {
+	if requested != "" {
+		for _, c := range slices.Concat(pod.Spec.Containers, pod.Spec.InitContainers) {
+			if c.Name == requested {
+				return requested, nil
+			}
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			if c.Name == requested {
+				return requested, nil
+			}
+		}
+		return "", fmt.Errorf("container %q not found in pod %s", requested, pod.Name)
+	}
+
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name, nil
+	}
+	if len(pod.Spec.Containers) > 1 {
+		fmt.Fprintf(errOut, "Defaulted container %q out of %d containers, use -c to choose another\n",
+			pod.Spec.Containers[0].Name, len(pod.Spec.Containers))
+	}
+	return pod.Spec.Containers[0].Name, nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// fanOutFlags are the --contexts and --all-contexts flags of the read-only
+// commands that can query several clusters at once.
+type fanOutFlags struct {
+	contexts    []string
+	allContexts bool
+}
+
+func (c *fanOutFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&c.contexts, "contexts", nil, "Run against these kubeconfig contexts concurrently and merge the results")
+	cmd.Flags().BoolVar(&c.allContexts, "all-contexts", false, "Run against every context of the kubeconfig concurrently and merge the results")
+	cmd.MarkFlagsMutuallyExclusive("contexts", "all-contexts")
+}
+
+// enabled reports whether the command should fan out.
+func (c *fanOutFlags) enabled() bool {
+	return len(c.contexts) > 0 || c.allContexts
+}
+
+// fetchFunc reads objects from the cluster of f and hands them to emit, one
+// page at a time.
+type fetchFunc func(ctx context.Context, f *kube.Factory, emit func([]runtime.Object) error) error
+
+// fanOut runs fetch against every selected context concurrently. Once all
+// clusters answered, the objects are printed in context order with a leading
+// CLUSTER column. A failing cluster is reported on errOut without hiding the
+// results of the others.
+func (c *fanOutFlags) fanOut(ctx context.Context, f *kube.Factory, opts printer.Options, out, errOut io.Writer, fetch fetchFunc) error {
+	switch format, _, _ := strings.Cut(opts.Format, "="); format {
+	case printer.FormatTable, printer.FormatWide, printer.FormatCustomColumns, printer.FormatCSV, printer.FormatTSV:
+	default:
+		return fmt.Errorf("output format %q cannot show the cluster, use the table, wide, custom-columns, csv or tsv output with --contexts", opts.Format)
+	}
+
+	contexts := c.contexts
+	if c.allContexts {
+		var err error
+		if contexts, err = f.Contexts(); err != nil {
+			return err
+		}
+	}
+
+	type result struct {
+		objects []runtime.Object
+		err     error
+	}
+	results := make([]result, len(contexts))
+
+	var wg sync.WaitGroup
+	for i, name := range contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every goroutine only touches its own slot of results.
+			results[i].err = fetch(ctx, f.ForContext(name), func(objs []runtime.Object) error {
+				results[i].objects = append(results[i].objects, objs...)
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	// Objects are pointers, so they identify the cluster they came from.
+	clusterOf := map[runtime.Object]string{}
+	opts.Leading = append([]printer.Column{{
+		Header: "CLUSTER",
+		Value:  func(obj runtime.Object) string { return clusterOf[obj] },
+	}}, opts.Leading...)
+	p, err := printer.New(out, opts)
+	if err != nil {
+		return err
+	}
+
+	// Everything is printed as one batch so the columns of all clusters are
+	// aligned with each other.
+	failed := 0
+	var merged []runtime.Object
+	for i, name := range contexts {
+		if results[i].err != nil {
+			failed++
+			fmt.Fprintf(errOut, "context %s: %v\n", name, results[i].err)
+			continue
+		}
+		for _, obj := range results[i].objects {
+			clusterOf[obj] = name
+		}
+		merged = append(merged, results[i].objects...)
+	}
+	if err := p.PrintObjects(merged); err != nil {
+		return err
+	}
+	if err := p.Flush(); err != nil {
+		return err
+	}
+
+	if p.Count() == 0 && failed == 0 && isTableFormat(opts.Format) {
+		fmt.Fprintln(out, "No resources found")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d contexts failed", failed, len(contexts))
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// fakePodFetch lists the pods of the fake clientset of the factory context.
+// Contexts without a clientset fail like an unreachable cluster.
+func fakePodFetch(clients map[string]kubernetes.Interface) fetchFunc {
+	return func(ctx context.Context, f *kube.Factory, emit func([]runtime.Object) error) error {
+		cs, ok := clients[f.Context]
+		if !ok {
+			return fmt.Errorf("cluster of %s is unreachable", f.Context)
+		}
+		list, err := cs.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		objs := make([]runtime.Object, len(list.Items))
+		for i := range list.Items {
+			objs[i] = &list.Items[i]
+		}
+		return emit(objs)
+	}
+}
+
+func fakePod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	clients := map[string]kubernetes.Interface{
+		"prod":    fake.NewClientset(fakePod("web-prod", corev1.PodRunning)),
+		"staging": fake.NewClientset(fakePod("web-staging", corev1.PodPending)),
+		"empty":   fake.NewClientset(),
+	}
+	phase := printer.Column{Header: "STATUS", Value: func(obj runtime.Object) string {
+		return string(obj.(*corev1.Pod).Status.Phase)
+	}}
+
+	tests := []struct {
+		name       string
+		contexts   []string
+		format     string
+		want       string
+		wantErrOut string
+		wantErr    string
+	}{
+		{
+			name:     "merged in context order",
+			contexts: []string{"staging", "prod"},
+			want: `
+CLUSTER   NAME          STATUS
+staging   web-staging   Pending
+prod      web-prod      Running
+`,
+		},
+		{
+			name:     "csv",
+			contexts: []string{"prod", "staging"},
+			format:   "csv=CLUSTER,NAME",
+			want: `
+CLUSTER,NAME
+prod,web-prod
+staging,web-staging
+`,
+		},
+		{
+			name:     "failing context does not hide the others",
+			contexts: []string{"prod", "offline", "staging"},
+			want: `
+CLUSTER   NAME          STATUS
+prod      web-prod      Running
+staging   web-staging   Pending
+`,
+			wantErrOut: "context offline: cluster of offline is unreachable\n",
+			wantErr:    "1 of 3 contexts failed",
+		},
+		{
+			name:     "no objects",
+			contexts: []string{"empty"},
+			want:     "No resources found\n",
+		},
+		{
+			name:     "no objects with csv output",
+			contexts: []string{"empty"},
+			format:   printer.FormatCSV,
+			want:     "",
+		},
+		{
+			name:     "format without columns",
+			contexts: []string{"prod"},
+			format:   printer.FormatJSON,
+			want:     "",
+			wantErr:  `output format "json" cannot show the cluster`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			c := &fanOutFlags{contexts: tt.contexts}
+			opts := printer.Options{Format: tt.format, Columns: []printer.Column{phase}}
+			err := c.fanOut(context.Background(), &kube.Factory{}, opts, &out, &errOut, fakePodFetch(clients))
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("fanOut() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("fanOut() error = %v", err)
+			}
+			if got, want := out.String(), strings.TrimPrefix(tt.want, "\n"); got != want {
+				t.Errorf("output =\n%s\nwant\n%s", got, want)
+			}
+			if got := errOut.String(); got != tt.wantErrOut {
+				t.Errorf("error output = %q, want %q", got, tt.wantErrOut)
+			}
+		})
+	}
+}
+
+func TestFanOutAllContexts(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	config := `apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster: {server: "https://127.0.0.1:6443"}
+users:
+- name: u
+  user: {}
+contexts:
+- name: staging
+  context: {cluster: c, user: u}
+- name: prod
+  context: {cluster: c, user: u}
+`
+	if err := os.WriteFile(kubeconfig, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	clients := map[string]kubernetes.Interface{
+		"prod":    fake.NewClientset(fakePod("web-prod", corev1.PodRunning)),
+		"staging": fake.NewClientset(fakePod("web-staging", corev1.PodPending)),
+	}
+
+	var out bytes.Buffer
+	c := &fanOutFlags{allContexts: true}
+	err := c.fanOut(context.Background(), &kube.Factory{Kubeconfig: kubeconfig}, printer.Options{Format: "csv=CLUSTER,NAME"}, &out, &bytes.Buffer{}, fakePodFetch(clients))
+	if err != nil {
+		t.Fatalf("fanOut() error = %v", err)
+	}
+	if want := "CLUSTER,NAME\nprod,web-prod\nstaging,web-staging\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// typedPage is one page of objects returned by a typed list call.
+type typedPage struct {
+	objects []runtime.Object
+	// continueToken is set when more pages are available.
+	continueToken string
+}
+
+// typedResource describes how one kind is listed through the typed clientset
+// and which columns its table output shows.
+type typedResource struct {
+	list    func(ctx context.Context, cs kubernetes.Interface, namespace string, opts metav1.ListOptions) (*typedPage, error)
+	columns []printer.Column
+}
+
+var (
+	podsResource = typedResource{list: listPods, columns: []printer.Column{
+		{Header: "READY", Value: podReady},
+		{Header: "STATUS", Value: podStatus},
+		{Header: "RESTARTS", Value: podRestarts},
+		{Header: "AGE", Value: objectAge},
+		{Header: "IP", Wide: true, Value: func(obj runtime.Object) string { return orNone(obj.(*corev1.Pod).Status.PodIP) }},
+		{Header: "NODE", Wide: true, Value: func(obj runtime.Object) string { return orNone(obj.(*corev1.Pod).Spec.NodeName) }},
+	}}
+	deploymentsResource = typedResource{list: listDeployments, columns: []printer.Column{
+		{Header: "READY", Value: deploymentReady},
+		{Header: "UP-TO-DATE", Value: func(obj runtime.Object) string { return fmt.Sprint(obj.(*appsv1.Deployment).Status.UpdatedReplicas) }},
+		{Header: "AVAILABLE", Value: func(obj runtime.Object) string { return fmt.Sprint(obj.(*appsv1.Deployment).Status.AvailableReplicas) }},
+		{Header: "AGE", Value: objectAge},
+		{Header: "CONTAINERS", Wide: true, Value: deploymentContainers},
+		{Header: "IMAGES", Wide: true, Value: deploymentImages},
+		{Header: "SELECTOR", Wide: true, Value: deploymentSelector},
+	}}
+	servicesResource = typedResource{list: listServices, columns: []printer.Column{
+		{Header: "TYPE", Value: func(obj runtime.Object) string { return string(obj.(*corev1.Service).Spec.Type) }},
+		{Header: "CLUSTER-IP", Value: func(obj runtime.Object) string { return orNone(obj.(*corev1.Service).Spec.ClusterIP) }},
+		{Header: "PORT(S)", Value: servicePorts},
+		{Header: "AGE", Value: objectAge},
+		{Header: "SELECTOR", Wide: true, Value: func(obj runtime.Object) string {
+			return orNone(labels.SelectorFromSet(obj.(*corev1.Service).Spec.Selector).String())
+		}},
+	}}
+)
+
+// typedResources maps every accepted resource name (including kubectl style
+// short names) to its typed resource.
+var typedResources = map[string]typedResource{
+	"pods":        podsResource,
+	"pod":         podsResource,
+	"po":          podsResource,
+	"deployments": deploymentsResource,
+	"deployment":  deploymentsResource,
+	"deploy":      deploymentsResource,
+	"services":    servicesResource,
+	"service":     servicesResource,
+	"svc":         servicesResource,
+}
+
+// listOptions holds the flags of the list command.
+type listOptions struct {
+	factory       *kube.Factory
+	allNamespaces bool
+	chunkSize     int64
+	selectors     selectorFlags
+	output        string
+	fanOut        fanOutFlags
+}
+
+func newListCmd(f *kube.Factory) *cobra.Command {
+	o := &listOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:       "list (pods|deployments|services)",
+		Short:     "List objects using the typed clientset",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"pods", "deployments", "services"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "List objects across all namespaces")
+	addChunkSizeFlag(cmd, &o.chunkSize)
+	addOutputFlag(cmd, &o.output, outputFormatsAll)
+	o.selectors.addFlags(cmd)
+	o.fanOut.addFlags(cmd)
+
+	return cmd
+}
+
+func (o *listOptions) run(ctx context.Context, out, errOut io.Writer, resource string) error {
+	res, ok := typedResources[strings.ToLower(resource)]
+	if !ok {
+		return fmt.Errorf("unsupported resource %q, expected one of pods, deployments, services", resource)
+	}
+	if err := o.selectors.validate(); err != nil {
+		return err
+	}
+
+	printOpts := printer.Options{
+		Format:        o.output,
+		Columns:       res.columns,
+		WithNamespace: o.allNamespaces,
+	}
+	fetch := func(ctx context.Context, f *kube.Factory, emit func([]runtime.Object) error) error {
+		return o.list(ctx, f, res, emit)
+	}
+	if o.fanOut.enabled() {
+		return o.fanOut.fanOut(ctx, o.factory, printOpts, out, errOut, fetch)
+	}
+
+	p, err := printer.New(out, printOpts)
+	if err != nil {
+		return err
+	}
+	if err := fetch(ctx, o.factory, p.PrintObjects); err != nil {
+		return err
+	}
+	if err := p.Flush(); err != nil {
+		return err
+	}
+
+	if p.Count() == 0 && isTableFormat(o.output) {
+		namespace, err := resolveNamespace(o.factory, o.allNamespaces)
+		if err != nil {
+			return err
+		}
+		if o.allNamespaces {
+			fmt.Fprintln(out, "No resources found")
+		} else {
+			fmt.Fprintf(out, "No resources found in %s namespace.\n", namespace)
+		}
+	}
+	return nil
+}
+
+// list reads the objects from the cluster of f page by page. Every page is
+// handed to emit as soon as it arrives, so huge lists neither need one giant
+// response nor have to be held in memory.
+func (o *listOptions) list(ctx context.Context, f *kube.Factory, res typedResource, emit func([]runtime.Object) error) error {
+	cs, err := f.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace, err := resolveNamespace(f, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+
+	opts := o.selectors.listOptions()
+	opts.Limit = o.chunkSize
+	for {
+		page, err := res.list(ctx, cs, namespace, opts)
+		if err != nil {
+			return pagingError(err)
+		}
+		if err := emit(page.objects); err != nil {
+			return err
+		}
+		if page.continueToken == "" {
+			return nil
+		}
+		opts.Continue = page.continueToken
+	}
+}
+
+// The items of typed lists carry no apiVersion/kind. The list functions set
+// it so that the JSON, YAML and name output is complete.
+
+func listPods(ctx context.Context, cs kubernetes.Interface, namespace string, opts metav1.ListOptions) (*typedPage, error) {
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	page := &typedPage{continueToken: pods.Continue}
+	for i := range pods.Items {
+		pods.Items[i].SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+		page.objects = append(page.objects, &pods.Items[i])
+	}
+	return page, nil
+}
+
+func listDeployments(ctx context.Context, cs kubernetes.Interface, namespace string, opts metav1.ListOptions) (*typedPage, error) {
+	deployments, err := cs.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	page := &typedPage{continueToken: deployments.Continue}
+	for i := range deployments.Items {
+		deployments.Items[i].SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+		page.objects = append(page.objects, &deployments.Items[i])
+	}
+	return page, nil
+}
+
+func listServices(ctx context.Context, cs kubernetes.Interface, namespace string, opts metav1.ListOptions) (*typedPage, error) {
+	services, err := cs.CoreV1().Services(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	page := &typedPage{continueToken: services.Continue}
+	for i := range services.Items {
+		services.Items[i].SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+		page.objects = append(page.objects, &services.Items[i])
+	}
+	return page, nil
+}
+
+func podReady(obj runtime.Object) string {
+	pod := obj.(*corev1.Pod)
+	ready := 0
+	for _, cst := range pod.Status.ContainerStatuses {
+		if cst.Ready {
+			ready++
+		}
+	}
+	return fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers))
+}
+
+func podStatus(obj runtime.Object) string {
+	pod := obj.(*corev1.Pod)
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	return string(pod.Status.Phase)
+}
+
+func podRestarts(obj runtime.Object) string {
+	var restarts int32
+	for _, cst := range obj.(*corev1.Pod).Status.ContainerStatuses {
+		restarts += cst.RestartCount
+	}
+	return fmt.Sprint(restarts)
+}
+
+func deploymentReady(obj runtime.Object) string {
+	d := obj.(*appsv1.Deployment)
+	var desired int32 = 1
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	return fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, desired)
+}
+
+func deploymentContainers(obj runtime.Object) string {
+	var names []string
+	for _, c := range obj.(*appsv1.Deployment).Spec.Template.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func deploymentImages(obj runtime.Object) string {
+	var images []string
+	for _, c := range obj.(*appsv1.Deployment).Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+	return strings.Join(images, ",")
+}
+
+func deploymentSelector(obj runtime.Object) string {
+	selector, err := metav1.LabelSelectorAsSelector(obj.(*appsv1.Deployment).Spec.Selector)
+	if err != nil {
+		return "<invalid>"
+	}
+	return orNone(selector.String())
+}
+
+func servicePorts(obj runtime.Object) string {
+	svc := obj.(*corev1.Service)
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		if p.NodePort != 0 {
+			ports = append(ports, fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
+		}
+	}
+	return orNone(strings.Join(ports, ","))
+}
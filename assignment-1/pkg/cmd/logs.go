@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// restartPollInterval is how often a followed pod is checked while waiting
+// for a restarted container to come up again.
+const restartPollInterval = time.Second
+
+// logsOptions holds the flags of the logs command.
+type logsOptions struct {
+	factory    *kube.Factory
+	container  string
+	follow     bool
+	previous   bool
+	timestamps bool
+	since      time.Duration
+	tail       int64
+}
+
+func newLogsCmd(f *kube.Factory) *cobra.Command {
+	o := &logsOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "logs <pod> [-c container]",
+		Short: "Print or follow the logs of a container",
+		Long: `Logs streams the log of a container from the pods/log subresource.
+
+With -f the stream stays open. When the container exits and is restarted by
+the kubelet, the stream of the new instance is picked up automatically; the
+command ends when the pod finishes or is deleted.`,
+		Example: `  k8sctl logs web-5d4c
+  k8sctl logs pod/web-5d4c -c sidecar --tail=20
+  k8sctl logs web-5d4c -f --since=1h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&o.container, "container", "c", "", "Container name, defaults to the default or first container of the pod")
+	cmd.Flags().BoolVarP(&o.follow, "follow", "f", false, "Keep streaming new log lines, across container restarts")
+	cmd.Flags().BoolVarP(&o.previous, "previous", "p", false, "Print the log of the previous, terminated instance of the container")
+	cmd.Flags().BoolVar(&o.timestamps, "timestamps", false, "Prefix every line with its RFC3339 timestamp")
+	cmd.Flags().DurationVar(&o.since, "since", 0, "Only return lines newer than this duration, e.g. 10s, 5m or 1h")
+	cmd.Flags().Int64Var(&o.tail, "tail", -1, "Only return this many of the most recent lines, -1 returns all")
+	cmd.MarkFlagsMutuallyExclusive("follow", "previous")
+
+	return cmd
+}
+
+func (o *logsOptions) run(ctx context.Context, out, errOut io.Writer, podName string) error {
+	if resource, name, ok := strings.Cut(podName, "/"); ok {
+		switch strings.ToLower(resource) {
+		case "pods", "pod", "po":
+			podName = name
+		default:
+			return fmt.Errorf("logs only supports pods, got %q", resource)
+		}
+	}
+
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	container, err := selectContainer(pod, o.container, errOut)
+	if err != nil {
+		return err
+	}
+
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     o.follow,
+		Previous:   o.previous,
+		Timestamps: o.timestamps,
+	}
+	if o.since > 0 {
+		opts.SinceSeconds = ptr.To(int64(o.since.Round(time.Second).Seconds()))
+	}
+	if o.tail >= 0 {
+		opts.TailLines = ptr.To(o.tail)
+	}
+
+	restarts := restartCount(pod, container)
+	for {
+		if err := streamLogs(ctx, cs, namespace, podName, opts, out); err != nil {
+			return err
+		}
+		if !o.follow || ctx.Err() != nil {
+			return nil
+		}
+
+		// The stream of a followed container ends when the container exits.
+		// Wait for the kubelet to restart it and continue with the new
+		// instance, unless the pod is done for good.
+		ended := metav1.Now()
+		next, err := waitForRestart(ctx, cs, namespace, podName, container, restarts)
+		if err != nil || next < 0 {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if next > restarts {
+			fmt.Fprintf(errOut, "container %s restarted (restart %d), following the new instance\n", container, next)
+			// The new instance starts with an empty log.
+			opts.SinceSeconds, opts.TailLines, opts.SinceTime = nil, nil, nil
+		} else {
+			// Same instance, the connection was dropped: resume where it
+			// ended. Lines logged in the same second may be repeated.
+			fmt.Fprintf(errOut, "log stream of container %s was interrupted, reconnecting\n", container)
+			opts.SinceSeconds, opts.TailLines, opts.SinceTime = nil, nil, &ended
+		}
+		restarts = next
+	}
+}
+
+// streamLogs copies one log stream of the container to out until the stream
+// ends or ctx is cancelled.
+func streamLogs(ctx context.Context, cs kubernetes.Interface, namespace, pod string, opts *corev1.PodLogOptions, out io.Writer) error {
+	stream, err := cs.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	if _, err := io.Copy(out, stream); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// waitForRestart polls the pod after the log stream of container ended. It
+// returns the new restart count once the container runs again, or -1 when the
+// pod finished or was deleted and no further log will be written. The first
+// check is delayed so the kubelet can report the exit of the container.
+func waitForRestart(ctx context.Context, cs kubernetes.Interface, namespace, podName, container string, restarts int32) (int32, error) {
+	next := int32(-1)
+	err := wait.PollUntilContextCancel(ctx, restartPollInterval, false, func(ctx context.Context) (bool, error) {
+		pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return true, nil
+		}
+		status := containerStatus(pod, container)
+		if status == nil || status.State.Running == nil {
+			return false, nil
+		}
+		next = status.RestartCount
+		return true, nil
+	})
+	return next, err
+}
+
+// containerStatus returns the status of the named regular or init container.
+func containerStatus(pod *corev1.Pod, container string) *corev1.ContainerStatus {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for i := range statuses {
+			if statuses[i].Name == container {
+				return &statuses[i]
+			}
+		}
+	}
+	return nil
+}
+
+// restartCount returns how often the container was restarted so far.
+func restartCount(pod *corev1.Pod, container string) int32 {
+	if status := containerStatus(pod, container); status != nil {
+		return status.RestartCount
+	}
+	return 0
+}
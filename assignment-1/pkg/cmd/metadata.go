@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// metadataMap describes one of the two string maps of the object metadata
+// edited by the label and annotate commands.
+type metadataMap struct {
+	// field is the metadata field, labels or annotations.
+	field string
+	// verb is printed after the object, e.g. "labeled".
+	verb string
+	// validateValue checks a new value; annotation values are free form.
+	validateValue func(value string) []string
+}
+
+var (
+	labelsMap      = metadataMap{field: "labels", verb: "labeled", validateValue: validation.IsValidLabelValue}
+	annotationsMap = metadataMap{field: "annotations", verb: "annotated", validateValue: func(string) []string { return nil }}
+)
+
+// metadataOptions holds the flags of the label and annotate commands.
+type metadataOptions struct {
+	factory         *kube.Factory
+	target          metadataMap
+	overwrite       bool
+	patch           bool
+	resourceVersion string
+	output          string
+}
+
+func newLabelCmd(f *kube.Factory) *cobra.Command {
+	return newMetadataCmd(f, labelsMap, "label", `  k8sctl label deploy web tier=frontend
+  k8sctl label pod/web-5d4c tier=backend --overwrite
+  k8sctl label deploy/web tier-
+  k8sctl label deploy/web release=v2 --patch --overwrite`)
+}
+
+func newAnnotateCmd(f *kube.Factory) *cobra.Command {
+	return newMetadataCmd(f, annotationsMap, "annotate", `  k8sctl annotate deploy web owner=team-a
+  k8sctl annotate svc/web description='public entry point' --overwrite
+  k8sctl annotate svc/web description-`)
+}
+
+func newMetadataCmd(f *kube.Factory, target metadataMap, use, example string) *cobra.Command {
+	o := &metadataOptions{factory: f, target: target}
+
+	cmd := &cobra.Command{
+		Use:   use + " (<resource> <name> | <resource>/<name>) KEY=VALUE... KEY-...",
+		Short: "Add, change or remove the " + target.field + " of an object",
+		Long: `Sets KEY=VALUE and removes KEY- in the ` + target.field + ` of a live object.
+Changing an existing value requires --overwrite.
+
+By default the object is read, modified and written back with Update. The
+Update carries the resourceVersion that was read, so the API server rejects it
+with 409 Conflict when someone else changed the object in between; the
+read-modify-write is then retried on the fresh object (retry.RetryOnConflict).
+With --resource-version the update only succeeds against exactly that version
+and is not retried.
+
+--patch sends a single JSON merge patch of the ` + target.field + ` instead, which
+cannot conflict because it only touches the given keys. The current values
+are not read, so it requires --overwrite.`,
+		Example: example,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource, name, changes := args[0], "", args[1:]
+			if r, n, ok := strings.Cut(args[0], "/"); ok {
+				resource, name = r, n
+			} else {
+				name, changes = args[1], args[2:]
+			}
+			p, err := newObjectPrinter(cmd, o.output, outputFormatsObjects)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), p, resource, name, changes)
+		},
+	}
+	cmd.Flags().BoolVar(&o.overwrite, "overwrite", false, "Allow changing keys that already have a value")
+	cmd.Flags().BoolVar(&o.patch, "patch", false, "Send one merge patch instead of a read-modify-write Update")
+	cmd.Flags().StringVar(&o.resourceVersion, "resource-version", "", "Only update the object if it is still at this resourceVersion")
+	addOutputFlag(cmd, &o.output, outputFormatsObjects)
+
+	return cmd
+}
+
+func (o *metadataOptions) run(ctx context.Context, out, errOut io.Writer, p printer.Printer, resource, name string, changes []string) error {
+	set, remove, err := o.parseChanges(changes)
+	if err != nil {
+		return err
+	}
+	if o.patch && !o.overwrite && len(set) > 0 {
+		return fmt.Errorf("--patch does not read the current %s, add --overwrite to confirm existing keys may be replaced", o.target.field)
+	}
+
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return err
+	}
+
+	var live *unstructured.Unstructured
+	changed := true
+	if o.patch {
+		live, err = o.mergePatch(ctx, client, name, set, remove)
+	} else {
+		live, changed, err = o.update(ctx, client, errOut, name, set, remove)
+	}
+	if err != nil {
+		return err
+	}
+
+	if p != nil {
+		if err := p.PrintObjects([]runtime.Object{live}); err != nil {
+			return err
+		}
+		return p.Flush()
+	}
+	ref := printer.KindGroup(mapping.GroupVersionKind) + "/" + name
+	if !changed {
+		fmt.Fprintf(out, "%s %s (no change)\n", ref, o.target.verb)
+		return nil
+	}
+	fmt.Fprintf(out, "%s %s\n", ref, o.target.verb)
+	return nil
+}
+
+// parseChanges splits the KEY=VALUE and KEY- arguments and validates them.
+func (o *metadataOptions) parseChanges(changes []string) (map[string]string, []string, error) {
+	if len(changes) == 0 {
+		return nil, nil, fmt.Errorf("at least one KEY=VALUE or KEY- is required")
+	}
+	set := map[string]string{}
+	var remove []string
+	for _, change := range changes {
+		key, value, isSet := strings.Cut(change, "=")
+		if !isSet {
+			if !strings.HasSuffix(change, "-") {
+				return nil, nil, fmt.Errorf("invalid change %q, expected KEY=VALUE or KEY-", change)
+			}
+			key = strings.TrimSuffix(change, "-")
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if !isSet {
+			remove = append(remove, key)
+			continue
+		}
+		if errs := o.target.validateValue(value); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid value %q for %s: %s", value, key, strings.Join(errs, "; "))
+		}
+		set[key] = value
+	}
+	for _, key := range remove {
+		if _, ok := set[key]; ok {
+			return nil, nil, fmt.Errorf("%s is both set and removed", key)
+		}
+	}
+	return set, remove, nil
+}
+
+// update applies the changes with Get-modify-Update. A 409 Conflict means the
+// object changed since it was read; RetryOnConflict then runs the whole
+// function again, so the changes are re-applied to the fresh object and the
+// --overwrite check sees its current values.
+func (o *metadataOptions) update(ctx context.Context, client dynamic.ResourceInterface, errOut io.Writer, name string, set map[string]string, remove []string) (*unstructured.Unstructured, bool, error) {
+	var result *unstructured.Unstructured
+	changed := false
+	attempt := 0
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if attempt++; attempt > 1 {
+			fmt.Fprintf(errOut, "%s was modified concurrently, retrying with the latest version (attempt %d)\n", name, attempt)
+		}
+		live, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if o.resourceVersion != "" && live.GetResourceVersion() != o.resourceVersion {
+			return fmt.Errorf("%s is at resourceVersion %s, not %s", name, live.GetResourceVersion(), o.resourceVersion)
+		}
+
+		values, _, err := unstructured.NestedStringMap(live.Object, "metadata", o.target.field)
+		if err != nil {
+			return err
+		}
+		if values == nil {
+			values = map[string]string{}
+		}
+		before := maps.Clone(values)
+		for key, value := range set {
+			if current, ok := values[key]; ok && current != value && !o.overwrite {
+				return fmt.Errorf("%s already has a value (%s), use --overwrite to change it", key, current)
+			}
+			values[key] = value
+		}
+		for _, key := range remove {
+			delete(values, key)
+		}
+		if maps.Equal(before, values) {
+			result, changed = live, false
+			return nil
+		}
+
+		if o.target.field == "labels" {
+			live.SetLabels(values)
+		} else {
+			live.SetAnnotations(values)
+		}
+		// live still carries the resourceVersion it was read at, which makes
+		// the Update conditional.
+		result, err = client.Update(ctx, live, metav1.UpdateOptions{FieldManager: defaultFieldManager})
+		changed = err == nil
+		return err
+	})
+	if apierrors.IsConflict(err) && o.resourceVersion == "" {
+		return nil, false, fmt.Errorf("giving up after %d conflicting updates: %w", attempt, err)
+	}
+	return result, changed, err
+}
+
+// mergePatch applies the changes with one JSON merge patch. Keys set to null
+// are removed. With --resource-version the patch also carries
+// metadata.resourceVersion, which the API server treats as a precondition.
+func (o *metadataOptions) mergePatch(ctx context.Context, client dynamic.ResourceInterface, name string, set map[string]string, remove []string) (*unstructured.Unstructured, error) {
+	values := map[string]any{}
+	for key, value := range set {
+		values[key] = value
+	}
+	for _, key := range remove {
+		values[key] = nil
+	}
+	metadata := map[string]any{o.target.field: values}
+	if o.resourceVersion != "" {
+		metadata["resourceVersion"] = o.resourceVersion
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": metadata})
+	if err != nil {
+		return nil, err
+	}
+	return client.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: defaultFieldManager})
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     metadataMap
+		changes    []string
+		wantSet    map[string]string
+		wantRemove []string
+		wantErr    string
+	}{
+		{
+			name:    "set and remove",
+			target:  labelsMap,
+			changes: []string{"app=web", "tier-", "app.kubernetes.io/part-of=shop"},
+			wantSet: map[string]string{
+				"app":                       "web",
+				"app.kubernetes.io/part-of": "shop",
+			},
+			wantRemove: []string{"tier"},
+		},
+		{
+			name:    "empty value",
+			target:  labelsMap,
+			changes: []string{"canary="},
+			wantSet: map[string]string{"canary": ""},
+		},
+		{
+			name:    "annotation values are free form",
+			target:  annotationsMap,
+			changes: []string{"description=serves the shop front end, v2"},
+			wantSet: map[string]string{"description": "serves the shop front end, v2"},
+		},
+		{
+			name:    "value containing =",
+			target:  annotationsMap,
+			changes: []string{"query=a=b"},
+			wantSet: map[string]string{"query": "a=b"},
+		},
+		{
+			name:    "no changes",
+			target:  labelsMap,
+			wantErr: "at least one KEY=VALUE or KEY- is required",
+		},
+		{
+			name:    "neither set nor remove",
+			target:  labelsMap,
+			changes: []string{"app"},
+			wantErr: `invalid change "app"`,
+		},
+		{
+			name:    "invalid key",
+			target:  labelsMap,
+			changes: []string{"-app=web"},
+			wantErr: `invalid key "-app"`,
+		},
+		{
+			name:    "invalid label value",
+			target:  labelsMap,
+			changes: []string{"description=serves the shop"},
+			wantErr: `invalid value "serves the shop" for description`,
+		},
+		{
+			name:    "set and removed",
+			target:  labelsMap,
+			changes: []string{"app=web", "app-"},
+			wantErr: "app is both set and removed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &metadataOptions{target: tt.target}
+			set, remove, err := o.parseChanges(tt.changes)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseChanges() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseChanges() error = %v", err)
+			}
+			if !reflect.DeepEqual(set, tt.wantSet) {
+				t.Errorf("parseChanges() set = %v, want %v", set, tt.wantSet)
+			}
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("parseChanges() remove = %q, want %q", remove, tt.wantRemove)
+			}
+		})
+	}
+}
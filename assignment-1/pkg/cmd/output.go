@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// Output formats offered by the commands. Commands printing lists support
+// every format, commands modifying objects print the resulting objects.
+const (
+	outputFormatsAll     = "json|yaml|wide|name|custom-columns=SPEC|csv[=COLUMNS]|tsv[=COLUMNS]"
+	outputFormatsObjects = "json|yaml|name"
+)
+
+// addOutputFlag registers the -o/--output flag.
+func addOutputFlag(cmd *cobra.Command, output *string, formats string) {
+	cmd.Flags().StringVarP(output, "output", "o", "", "Output format, one of: "+formats)
+}
+
+// newObjectPrinter returns the printer used by the commands that modify
+// objects, or nil when no -o was given and the command prints its own
+// summary lines. formats lists the accepted formats separated by "|".
+func newObjectPrinter(cmd *cobra.Command, format, formats string) (printer.Printer, error) {
+	if format == "" {
+		return nil, nil
+	}
+	for _, allowed := range strings.Split(formats, "|") {
+		if format == allowed {
+			return printer.New(cmd.OutOrStdout(), printer.Options{Format: format, SingleObject: true})
+		}
+	}
+	return nil, fmt.Errorf("unsupported output format %q, expected one of: %s", format, formats)
+}
+
+// isTableFormat reports whether the output is a human readable table, which
+// is the only format that reports an empty result in words.
+func isTableFormat(format string) bool {
+	return format == printer.FormatTable || format == printer.FormatWide
+}
+
+// objectAge is the AGE column shared by all tables.
+func objectAge(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "<unknown>"
+	}
+	return age(accessor.GetCreationTimestamp())
+}
+
+// orNone renders empty cells as kubectl does.
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// patchTypes maps the --type flag values to the patch content types.
+var patchTypes = map[string]types.PatchType{
+	"json":      types.JSONPatchType,
+	"merge":     types.MergePatchType,
+	"strategic": types.StrategicMergePatchType,
+}
+
+// patchOptions holds the flags of the patch command.
+type patchOptions struct {
+	factory   *kube.Factory
+	patchType string
+	patch     string
+	patchFile string
+	output    string
+}
+
+func newPatchCmd(f *kube.Factory) *cobra.Command {
+	o := &patchOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "patch <resource> <name> -p PATCH",
+		Short: "Patch a live object with a JSON, merge or strategic merge patch",
+		Long: `Patch sends the patch to the API server with the selected content type:
+
+  json       RFC 6902 JSON patch, a list of add/remove/replace operations
+  merge      RFC 7386 JSON merge patch, lists are replaced as a whole
+  strategic  Kubernetes strategic merge patch, lists are merged using the
+             patch strategy of the Go types (built-in kinds only)
+
+The patch may be written as JSON or YAML.`,
+		Example: `  k8sctl patch deploy web --type=strategic -p '{"spec":{"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.27"}]}}}}'
+  k8sctl patch deploy web --type=merge -p '{"spec":{"replicas":3}}'
+  k8sctl patch cm settings --type=json -p '[{"op":"remove","path":"/data/debug"}]'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := newObjectPrinter(cmd, o.output, outputFormatsObjects)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.OutOrStdout(), p, args[0], args[1])
+		},
+	}
+	cmd.Flags().StringVar(&o.patchType, "type", "strategic", "Patch type: json, merge or strategic")
+	cmd.Flags().StringVarP(&o.patch, "patch", "p", "", "The patch to apply, as JSON or YAML")
+	cmd.Flags().StringVar(&o.patchFile, "patch-file", "", "File containing the patch")
+	addOutputFlag(cmd, &o.output, outputFormatsObjects)
+	cmd.MarkFlagsOneRequired("patch", "patch-file")
+	cmd.MarkFlagsMutuallyExclusive("patch", "patch-file")
+
+	return cmd
+}
+
+func (o *patchOptions) run(ctx context.Context, out io.Writer, p printer.Printer, resource, name string) error {
+	patchType, ok := patchTypes[o.patchType]
+	if !ok {
+		return fmt.Errorf("unknown patch type %q, expected json, merge or strategic", o.patchType)
+	}
+
+	patch := []byte(o.patch)
+	if o.patchFile != "" {
+		var err error
+		if patch, err = os.ReadFile(o.patchFile); err != nil {
+			return err
+		}
+	}
+	// The API server only accepts JSON for these patch types.
+	patch, err := utilyaml.ToJSON(patch)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return err
+	}
+
+	// The resourceVersion before the patch tells whether anything changed.
+	before, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	after, err := client.Patch(ctx, name, patchType, patch, metav1.PatchOptions{FieldManager: defaultFieldManager})
+	if err != nil {
+		if patchType == types.StrategicMergePatchType && apierrors.IsUnsupportedMediaType(err) {
+			return fmt.Errorf("strategic merge patch is not supported for %s, use --type=merge or --type=json", printer.KindGroup(mapping.GroupVersionKind))
+		}
+		return err
+	}
+
+	if p != nil {
+		if err := p.PrintObjects([]runtime.Object{after}); err != nil {
+			return err
+		}
+		return p.Flush()
+	}
+
+	ref := printer.KindGroup(mapping.GroupVersionKind) + "/" + name
+	if before.GetResourceVersion() == after.GetResourceVersion() {
+		fmt.Fprintf(out, "%s patched (no change)\n", ref)
+		return nil
+	}
+	fmt.Fprintf(out, "%s patched\n", ref)
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// portForwardOptions holds the flags of the port-forward command.
+type portForwardOptions struct {
+	factory   *kube.Factory
+	addresses []string
+}
+
+func newPortForwardCmd(f *kube.Factory) *cobra.Command {
+	o := &portForwardOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "port-forward <pod> [LOCAL_PORT:]REMOTE_PORT...",
+		Short: "Forward local ports to a pod",
+		Long: `Port-forward listens on local ports and tunnels every connection to the
+matching port of the pod through the pods/portforward subresource.
+
+Ports are given as LOCAL:REMOTE, as REMOTE (the same port locally) or as
+:REMOTE (a random free local port). The command runs until it is interrupted
+with Ctrl-C.`,
+		Example: `  k8sctl port-forward pod/web-5d4c 8080:80
+  k8sctl port-forward web-5d4c 8080:80 8443:443
+  k8sctl port-forward web-5d4c :5432 --address=0.0.0.0`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], args[1:])
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.addresses, "address", []string{"localhost"}, "Local addresses to listen on, IPs or localhost")
+
+	return cmd
+}
+
+func (o *portForwardOptions) run(ctx context.Context, out, errOut io.Writer, podName string, ports []string) error {
+	if resource, name, ok := strings.Cut(podName, "/"); ok {
+		switch strings.ToLower(resource) {
+		case "pods", "pod", "po":
+			podName = name
+		default:
+			return fmt.Errorf("port-forward only supports pods, got %q", resource)
+		}
+	}
+
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Errorf("unable to forward ports to pod %s, phase is %s", podName, pod.Status.Phase)
+	}
+
+	cfg, err := o.factory.RESTConfig()
+	if err != nil {
+		return err
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return err
+	}
+	url := cs.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	// Closing stopCh makes ForwardPorts close the listeners and return.
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stopCh)
+	}()
+
+	// The forwarder reports the listeners itself, but without the resolved
+	// random ports; they are printed from GetPorts once it is ready.
+	fw, err := portforward.NewOnAddresses(dialer, o.addresses, ports, stopCh, readyCh, io.Discard, errOut)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- fw.ForwardPorts() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-readyCh:
+	}
+	forwarded, err := fw.GetPorts()
+	if err != nil {
+		return err
+	}
+	for _, addr := range o.addresses {
+		for _, p := range forwarded {
+			fmt.Fprintf(out, "Forwarding from %s:%d -> %d\n", addr, p.Local, p.Remote)
+		}
+	}
+	fmt.Fprintln(out, "Press Ctrl-C to stop")
+
+	return <-errCh
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// Annotations maintained by the deployment controller and kubectl.
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// rolloutOptions holds the flags shared by the rollout subcommands.
+type rolloutOptions struct {
+	factory  *kube.Factory
+	timeout  time.Duration
+	revision int64
+}
+
+func newRolloutCmd(f *kube.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "Follow, inspect and roll back Deployment rollouts",
+		Long: `Every change to the pod template of a Deployment creates a new ReplicaSet;
+the deployment controller numbers them in the deployment.kubernetes.io/revision
+annotation. The rollout commands work on those ReplicaSets.`,
+	}
+	cmd.AddCommand(newRolloutStatusCmd(f), newRolloutHistoryCmd(f), newRolloutUndoCmd(f))
+	return cmd
+}
+
+func newRolloutStatusCmd(f *kube.Factory) *cobra.Command {
+	o := &rolloutOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "status (deploy/<name> | deploy <name>)",
+		Short: "Follow a rollout until it completes",
+		Long: `Status watches the Deployment and prints the progress of the rollout each
+time it changes, until all replicas are updated and available. It fails when
+the rollout exceeds its progressDeadlineSeconds.`,
+		Example: `  k8sctl rollout status deploy/web
+  k8sctl rollout status deploy web --timeout=5m`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := o.deploymentName(args)
+			if err != nil {
+				return err
+			}
+			return o.status(cmd.Context(), cmd.OutOrStdout(), name)
+		},
+	}
+	cmd.Flags().DurationVar(&o.timeout, "timeout", 0, "How long to wait for the rollout, 0 waits forever")
+
+	return cmd
+}
+
+func newRolloutHistoryCmd(f *kube.Factory) *cobra.Command {
+	o := &rolloutOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "history (deploy/<name> | deploy <name>)",
+		Short: "List the revisions of a Deployment",
+		Example: `  k8sctl rollout history deploy/web
+  k8sctl rollout history deploy/web --revision=3`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := o.deploymentName(args)
+			if err != nil {
+				return err
+			}
+			return o.history(cmd.Context(), cmd.OutOrStdout(), name)
+		},
+	}
+	cmd.Flags().Int64Var(&o.revision, "revision", 0, "Print the pod template of this revision")
+
+	return cmd
+}
+
+func newRolloutUndoCmd(f *kube.Factory) *cobra.Command {
+	o := &rolloutOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "undo (deploy/<name> | deploy <name>)",
+		Short: "Roll a Deployment back to an earlier revision",
+		Long: `Undo copies the pod template of an earlier revision's ReplicaSet back into
+the Deployment. The deployment controller then rolls out that template again
+and, since it matches the old ReplicaSet, scales that one back up and gives
+it the next revision number.
+
+The rollback subresource and its rollbackTo field, which asked the
+controller to do this, only existed in extensions/v1beta1 and apps/v1beta1;
+with apps/v1 the client performs the rollback itself, as kubectl does.`,
+		Example: `  k8sctl rollout undo deploy/web
+  k8sctl rollout undo deploy/web --to-revision=2`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := o.deploymentName(args)
+			if err != nil {
+				return err
+			}
+			return o.undo(cmd.Context(), cmd.OutOrStdout(), name)
+		},
+	}
+	cmd.Flags().Int64Var(&o.revision, "to-revision", 0, "Revision to roll back to, 0 means the previous one")
+
+	return cmd
+}
+
+// deploymentName checks that the arguments name a Deployment and returns its
+// name.
+func (o *rolloutOptions) deploymentName(args []string) (string, error) {
+	resource, name, err := splitResourceName(args)
+	if err != nil {
+		return "", err
+	}
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return "", err
+	}
+	if gr := mapping.Resource.GroupResource(); gr != appsv1.SchemeGroupVersion.WithResource("deployments").GroupResource() {
+		return "", fmt.Errorf("rollout only supports deployments, got %s", gr)
+	}
+	return name, nil
+}
+
+func (o *rolloutOptions) status(ctx context.Context, out io.Writer, name string) error {
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	deployments := cs.AppsV1().Deployments(namespace)
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return deployments.List(ctx, opts)
+		},
+		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return deployments.Watch(ctx, opts)
+		},
+	}
+
+	// Progress is printed whenever the message changes, not on every event.
+	last := ""
+	precondition := func(store cache.Store) (bool, error) {
+		if len(store.List()) == 0 {
+			return false, fmt.Errorf("deployment %q not found", name)
+		}
+		return false, nil
+	}
+	_, err = watchtools.UntilWithSync(ctx, lw, &appsv1.Deployment{}, precondition, func(ev watch.Event) (bool, error) {
+		switch ev.Type {
+		case watch.Deleted:
+			return false, fmt.Errorf("deployment %q was deleted", name)
+		case watch.Added, watch.Modified:
+			message, done, err := rolloutStatus(ev.Object.(*appsv1.Deployment))
+			if err != nil {
+				return false, err
+			}
+			if message != last {
+				fmt.Fprintln(out, message)
+				last = message
+			}
+			return done, nil
+		}
+		return false, nil
+	})
+	// Once the context ended, whatever the watch returned is a timeout or
+	// an interrupt.
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for the rollout of deployment %q", name)
+	}
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// rolloutStatus describes the progress of a rollout and reports whether it is
+// complete, following the logic of kubectl rollout status.
+func rolloutStatus(d *appsv1.Deployment) (string, bool, error) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "Waiting for deployment spec update to be observed...", false, nil
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return "", false, fmt.Errorf("deployment %q exceeded its progress deadline", d.Name)
+		}
+	}
+
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	s := d.Status
+	switch {
+	case s.UpdatedReplicas < replicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", d.Name, s.UpdatedReplicas, replicas), false, nil
+	case s.Replicas > s.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", d.Name, s.Replicas-s.UpdatedReplicas), false, nil
+	case s.AvailableReplicas < s.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", d.Name, s.AvailableReplicas, s.UpdatedReplicas), false, nil
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out", d.Name), true, nil
+}
+
+func (o *rolloutOptions) history(ctx context.Context, out io.Writer, name string) error {
+	_, revisions, err := o.revisions(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if o.revision > 0 {
+		rs := findRevision(revisions, o.revision)
+		if rs == nil {
+			return fmt.Errorf("revision %d not found", o.revision)
+		}
+		template, err := yaml.Marshal(rs.Spec.Template)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "deployment %q revision %d (replicaset %s)\n\n%s", name, o.revision, rs.Name, template)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tREPLICASET\tREPLICAS\tAGE\tIMAGES\tCHANGE-CAUSE")
+	for _, rs := range revisions {
+		var images []string
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\n", revision(rs), rs.Name, rs.Status.Replicas,
+			age(rs.CreationTimestamp), strings.Join(images, ","), orNone(rs.Annotations[changeCauseAnnotation]))
+	}
+	return w.Flush()
+}
+
+func (o *rolloutOptions) undo(ctx context.Context, out io.Writer, name string) error {
+	d, revisions, err := o.revisions(ctx, name)
+	if err != nil {
+		return err
+	}
+	if d.Spec.Paused {
+		return fmt.Errorf("deployment %q is paused, resume it before rolling back", name)
+	}
+	if len(revisions) == 0 {
+		return fmt.Errorf("deployment %q has no revisions", name)
+	}
+
+	current := revision(revisions[len(revisions)-1])
+	target := o.revision
+	if target == 0 {
+		if len(revisions) < 2 {
+			return fmt.Errorf("deployment %q has no previous revision", name)
+		}
+		target = revision(revisions[len(revisions)-2])
+	}
+	rs := findRevision(revisions, target)
+	if rs == nil {
+		return fmt.Errorf("revision %d not found", target)
+	}
+
+	// The ReplicaSet template carries the pod-template-hash label added by
+	// the controller; it is not part of the Deployment template.
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	if apiequality.Semantic.DeepEqual(template, &d.Spec.Template) {
+		fmt.Fprintf(out, "deployment.apps/%s skipped rollback (current template already matches revision %d)\n", name, target)
+		return nil
+	}
+
+	// The test operation makes the patch fail if the Deployment changed
+	// since its ReplicaSets were read.
+	patch, err := json.Marshal([]map[string]any{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": d.ResourceVersion},
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return err
+	}
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+	if _, err := cs.AppsV1().Deployments(d.Namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{FieldManager: defaultFieldManager}); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "deployment.apps/%s rolled back from revision %d to %d\n", name, current, target)
+	return nil
+}
+
+// revisions returns the Deployment and the ReplicaSets it controls, ordered
+// by revision, oldest first.
+func (o *rolloutOptions) revisions(ctx context.Context, name string) (*appsv1.Deployment, []*appsv1.ReplicaSet, error) {
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return nil, nil, err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	rsList, err := controlledReplicaSets(ctx, cs, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(rsList, func(i, j int) bool { return revision(rsList[i]) < revision(rsList[j]) })
+	return d, rsList, nil
+}
+
+// controlledReplicaSets lists the ReplicaSets matching the Deployment
+// selector and keeps those whose controller is the Deployment itself.
+func controlledReplicaSets(ctx context.Context, cs kubernetes.Interface, d *appsv1.Deployment) ([]*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	list, err := cs.AppsV1().ReplicaSets(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var owned []*appsv1.ReplicaSet
+	for i := range list.Items {
+		if ref := metav1.GetControllerOf(&list.Items[i]); ref != nil && ref.UID == d.UID {
+			owned = append(owned, &list.Items[i])
+		}
+	}
+	return owned, nil
+}
+
+// revision returns the revision number of a ReplicaSet, 0 if unknown.
+func revision(rs *appsv1.ReplicaSet) int64 {
+	n, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	return n
+}
+
+func findRevision(revisions []*appsv1.ReplicaSet, n int64) *appsv1.ReplicaSet {
+	for _, rs := range revisions {
+		if revision(rs) == n {
+			return rs
+		}
+	}
+	return nil
+}
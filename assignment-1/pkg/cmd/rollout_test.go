@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestRolloutStatus(t *testing.T) {
+	deployment := func(generation, observed int64, replicas *int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		status.ObservedGeneration = observed
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		d        *appsv1.Deployment
+		want     string
+		wantDone bool
+		wantErr  string
+	}{
+		{
+			name: "spec update not observed",
+			d:    deployment(3, 2, ptr.To[int32](3), appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			want: "Waiting for deployment spec update to be observed...",
+		},
+		{
+			name: "replicas not yet updated",
+			d:    deployment(2, 2, ptr.To[int32](3), appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1}),
+			want: `Waiting for deployment "web" rollout to finish: 1 out of 3 new replicas have been updated...`,
+		},
+		{
+			name: "old replicas terminating",
+			d:    deployment(2, 2, ptr.To[int32](3), appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3}),
+			want: `Waiting for deployment "web" rollout to finish: 1 old replicas are pending termination...`,
+		},
+		{
+			name: "updated replicas not available",
+			d:    deployment(2, 2, ptr.To[int32](3), appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}),
+			want: `Waiting for deployment "web" rollout to finish: 2 of 3 updated replicas are available...`,
+		},
+		{
+			name:     "rolled out",
+			d:        deployment(2, 2, ptr.To[int32](3), appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			want:     `deployment "web" successfully rolled out`,
+			wantDone: true,
+		},
+		{
+			name: "replicas default to one",
+			d:    deployment(1, 1, nil, appsv1.DeploymentStatus{}),
+			want: `Waiting for deployment "web" rollout to finish: 0 out of 1 new replicas have been updated...`,
+		},
+		{
+			name:     "scaled to zero",
+			d:        deployment(1, 1, ptr.To[int32](0), appsv1.DeploymentStatus{}),
+			want:     `deployment "web" successfully rolled out`,
+			wantDone: true,
+		},
+		{
+			name: "progress deadline exceeded",
+			d: deployment(2, 2, ptr.To[int32](3), appsv1.DeploymentStatus{
+				Replicas:        4,
+				UpdatedReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentProgressing,
+					Status: "False",
+					Reason: "ProgressDeadlineExceeded",
+				}},
+			}),
+			wantErr: `deployment "web" exceeded its progress deadline`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, done, err := rolloutStatus(tt.d)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("rolloutStatus() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rolloutStatus() error = %v", err)
+			}
+			if got != tt.want || done != tt.wantDone {
+				t.Errorf("rolloutStatus() = %q, %t, want %q, %t", got, done, tt.want, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestControlledReplicaSets(t *testing.T) {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "d1"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	replicaSet := func(name, revision string, labels map[string]string, owner types.UID) *appsv1.ReplicaSet {
+		rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{revisionAnnotation: revision},
+		}}
+		if owner != "" {
+			rs.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "web",
+				UID:        owner,
+				Controller: ptr.To(true),
+			}}
+		}
+		return rs
+	}
+	web := map[string]string{"app": "web"}
+	cs := fake.NewClientset(
+		replicaSet("web-1", "1", web, "d1"),
+		replicaSet("web-2", "2", web, "d1"),
+		replicaSet("web-old", "1", web, "d0"),
+		replicaSet("web-adopted", "", web, ""),
+		replicaSet("api-1", "1", map[string]string{"app": "api"}, "d1"),
+	)
+
+	owned, err := controlledReplicaSets(context.Background(), cs, d)
+	if err != nil {
+		t.Fatalf("controlledReplicaSets() error = %v", err)
+	}
+	var names []string
+	for _, rs := range owned {
+		names = append(names, rs.Name)
+	}
+	sort.Strings(names)
+	if want := []string{"web-1", "web-2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("controlledReplicaSets() = %q, want %q", names, want)
+	}
+
+	if rs := findRevision(owned, 2); rs == nil || rs.Name != "web-2" {
+		t.Errorf("findRevision(2) = %v, want web-2", rs)
+	}
+	if rs := findRevision(owned, 3); rs != nil {
+		t.Errorf("findRevision(3) = %s, want nil", rs.Name)
+	}
+}
@@ -0,0 +1,52 @@
+// Package cmd implements the k8sctl sub-commands.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// NewRootCmd builds the k8sctl command tree. The global connection flags are
+// bound to a single kube.Factory which is shared with every sub-command.
+func NewRootCmd() *cobra.Command {
+	f := &kube.Factory{}
+
+	root := &cobra.Command{
+		Use:           "k8sctl",
+		Short:         "k8sctl explores the client-go client types against a live cluster",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&f.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file (defaults to $KUBECONFIG or ~/.kube/config, then in-cluster config)")
+	flags.StringVar(&f.Context, "context", "", "Name of the kubeconfig context to use")
+	flags.StringVarP(&f.Namespace, "namespace", "n", "", "Namespace to operate in (defaults to the context namespace)")
+
+	root.AddCommand(newListCmd(f))
+	root.AddCommand(newDynCmd(f))
+	root.AddCommand(newWatchCmd(f))
+	root.AddCommand(newApplyCmd(f))
+	root.AddCommand(newSSACmd(f))
+	root.AddCommand(newPatchCmd(f))
+	root.AddCommand(newScaleCmd(f))
+	root.AddCommand(newDeleteCmd(f))
+	root.AddCommand(newExecCmd(f))
+	root.AddCommand(newLogsCmd(f))
+	root.AddCommand(newPortForwardCmd(f))
+	root.AddCommand(newEventsCmd(f))
+	root.AddCommand(newTopCmd(f))
+	root.AddCommand(newLabelCmd(f))
+	root.AddCommand(newAnnotateCmd(f))
+	root.AddCommand(newBenchCmd(f))
+	root.AddCommand(newCacheServeCmd(f))
+	root.AddCommand(newWaitCmd(f))
+	root.AddCommand(newRolloutCmd(f))
+	root.AddCommand(newCordonCmd(f))
+	root.AddCommand(newUncordonCmd(f))
+	root.AddCommand(newDrainCmd(f))
+	root.AddCommand(newDiffCmd(f))
+
+	return root
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// scaleOptions holds the flags of the scale command.
+type scaleOptions struct {
+	factory         *kube.Factory
+	replicas        int32
+	currentReplicas int32
+	output          string
+}
+
+func newScaleCmd(f *kube.Factory) *cobra.Command {
+	o := &scaleOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "scale <resource>/<name> --replicas=COUNT",
+		Short: "Set the replica count through the scale subresource",
+		Long: `Scale reads and updates the /scale subresource (an autoscaling/v1 Scale
+object) instead of patching spec.replicas. The same code therefore works for
+Deployments, StatefulSets, ReplicaSets and any custom resource that enables
+the scale subresource.`,
+		Example: `  k8sctl scale deploy/web --replicas=5
+  k8sctl scale statefulset db --replicas=3 --current-replicas=1
+  k8sctl scale redis.cache.example.com/main --replicas=2`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource, name, err := splitResourceName(args)
+			if err != nil {
+				return err
+			}
+			p, err := newObjectPrinter(cmd, o.output, outputFormatsObjects)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.OutOrStdout(), p, resource, name)
+		},
+	}
+	cmd.Flags().Int32Var(&o.replicas, "replicas", 0, "The new desired number of replicas")
+	cmd.Flags().Int32Var(&o.currentReplicas, "current-replicas", -1, "Only scale when the current number of replicas matches, -1 disables the check")
+	addOutputFlag(cmd, &o.output, outputFormatsObjects)
+	_ = cmd.MarkFlagRequired("replicas")
+
+	return cmd
+}
+
+// run scales the object. With -o the updated Scale object is printed by p.
+func (o *scaleOptions) run(ctx context.Context, out io.Writer, p printer.Printer, resource, name string) error {
+	if o.replicas < 0 {
+		return fmt.Errorf("--replicas must not be negative")
+	}
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	scales, err := o.factory.ScaleClient()
+	if err != nil {
+		return err
+	}
+
+	gr := mapping.Resource.GroupResource()
+	current, err := scales.Scales(namespace).Get(ctx, gr, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if o.currentReplicas >= 0 && current.Spec.Replicas != o.currentReplicas {
+		return fmt.Errorf("expected %d current replicas, found %d", o.currentReplicas, current.Spec.Replicas)
+	}
+
+	// The Scale object carries the resourceVersion of the parent, so the
+	// update fails with a conflict if the object changed since the Get.
+	previous := current.Spec.Replicas
+	current.Spec.Replicas = o.replicas
+	updated, err := scales.Scales(namespace).Update(ctx, gr, current, metav1.UpdateOptions{FieldManager: defaultFieldManager})
+	if err != nil {
+		return err
+	}
+
+	if p != nil {
+		updated.SetGroupVersionKind(autoscalingv1.SchemeGroupVersion.WithKind("Scale"))
+		if err := p.PrintObjects([]runtime.Object{updated}); err != nil {
+			return err
+		}
+		return p.Flush()
+	}
+
+	fmt.Fprintf(out, "%s/%s scaled from %d to %d\n", printer.KindGroup(mapping.GroupVersionKind), name, previous, o.replicas)
+	return nil
+}
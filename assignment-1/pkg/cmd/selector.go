@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// selectorFlags are the -l/--selector and --field-selector flags shared by
+// every command that lists, watches or deletes several objects.
+type selectorFlags struct {
+	labelSelector string
+	fieldSelector string
+}
+
+func (s *selectorFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&s.labelSelector, "selector", "l", "", "Label selector to filter on, e.g. -l app=web,tier!=db")
+	cmd.Flags().StringVar(&s.fieldSelector, "field-selector", "", "Field selector to filter on, e.g. --field-selector status.phase=Running")
+}
+
+// isSet reports whether any selector was given.
+func (s *selectorFlags) isSet() bool {
+	return s.labelSelector != "" || s.fieldSelector != ""
+}
+
+// validate parses the selectors locally so that syntax errors are reported
+// before any request is sent.
+func (s *selectorFlags) validate() error {
+	if _, err := labels.Parse(s.labelSelector); err != nil {
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+	if _, err := fields.ParseSelector(s.fieldSelector); err != nil {
+		return fmt.Errorf("invalid field selector: %w", err)
+	}
+	return nil
+}
+
+// listOptions translates the selectors into ListOptions. Filtering happens
+// on the API server, only matching objects are returned.
+func (s *selectorFlags) listOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: s.labelSelector,
+		FieldSelector: s.fieldSelector,
+	}
+}
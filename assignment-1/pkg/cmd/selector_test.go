@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSelectorFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantSet bool
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "equality", args: []string{"-l", "app=web,tier!=db"}, wantSet: true},
+		{name: "set based", args: []string{"--selector", "environment in (prod, qa),!canary"}, wantSet: true},
+		{name: "field selector", args: []string{"--field-selector", "status.phase=Running,spec.nodeName!=node-1"}, wantSet: true},
+		{name: "both", args: []string{"-l", "app", "--field-selector", "metadata.name==web"}, wantSet: true},
+		{name: "label without key", args: []string{"-l", "=web"}, wantSet: true, wantErr: "invalid label selector"},
+		{name: "unterminated set", args: []string{"-l", "environment in (prod"}, wantSet: true, wantErr: "invalid label selector"},
+		{name: "invalid label value", args: []string{"-l", "app=web server"}, wantSet: true, wantErr: "invalid label selector"},
+		{name: "field without operator", args: []string{"--field-selector", "status.phase"}, wantSet: true, wantErr: "invalid field selector"},
+		{name: "set based field", args: []string{"-l", "app=web", "--field-selector", "status.phase in (Running)"}, wantSet: true, wantErr: "invalid field selector"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s selectorFlags
+			cmd := &cobra.Command{}
+			s.addFlags(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			if got := s.isSet(); got != tt.wantSet {
+				t.Errorf("isSet() = %t, want %t", got, tt.wantSet)
+			}
+
+			err := s.validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validate() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			opts := s.listOptions()
+			if opts.LabelSelector != s.labelSelector || opts.FieldSelector != s.fieldSelector {
+				t.Errorf("listOptions() = %+v, want the selectors %q and %q", opts, s.labelSelector, s.fieldSelector)
+			}
+		})
+	}
+}
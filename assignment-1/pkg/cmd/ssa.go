@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/manifest"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// defaultFieldManager is the field manager recorded in managedFields when
+// none is given on the command line.
+const defaultFieldManager = "k8sctl"
+
+// ssaOptions holds the flags of the ssa command.
+type ssaOptions struct {
+	factory        *kube.Factory
+	filenames      []string
+	fieldManager   string
+	forceConflicts bool
+	output         string
+}
+
+func newSSACmd(f *kube.Factory) *cobra.Command {
+	o := &ssaOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "ssa -f FILENAME",
+		Short: "Apply manifests with server-side apply",
+		Long: `Ssa sends every object of the manifests as an apply patch
+(application/apply-patch+yaml). The API server merges it with the live object
+and records the fields set by the object under --field-manager.
+
+When another manager owns a field with a different value the apply is
+rejected with a conflict; the contested fields and their owners are printed.
+Use --force-conflicts to take ownership of those fields.`,
+		Example: `  k8sctl ssa -f app.yaml
+  k8sctl ssa -f app.yaml --field-manager=ci --force-conflicts`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := newObjectPrinter(cmd, o.output, outputFormatsObjects)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), p)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&o.filenames, "filename", "f", nil, "Manifest file, directory or - for stdin")
+	cmd.Flags().StringVar(&o.fieldManager, "field-manager", defaultFieldManager, "Name of the manager owning the applied fields")
+	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, "Take ownership of fields owned by other managers")
+	addOutputFlag(cmd, &o.output, outputFormatsObjects)
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func (o *ssaOptions) run(ctx context.Context, in io.Reader, out, errOut io.Writer, p printer.Printer) error {
+	objects, err := manifest.ReadPaths(o.filenames, in)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, obj := range objects {
+		ref := printer.KindName(obj.Unstructured)
+		live, err := o.apply(ctx, obj.Unstructured, namespace)
+		if err != nil {
+			failed++
+			fmt.Fprintf(errOut, "%s failed (%s): %s\n", ref, obj.Source, describeApplyError(err))
+			continue
+		}
+		if p != nil {
+			if err := p.PrintObjects([]runtime.Object{live}); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(out, "%s serverside-applied\n", ref)
+	}
+	if p != nil {
+		if err := p.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to apply", failed, len(objects))
+	}
+	return nil
+}
+
+// apply sends obj as an apply patch and returns the resulting object. The
+// whole object is the patch: with server-side apply the body is the intent of
+// this field manager.
+func (o *ssaOptions) apply(ctx context.Context, obj *unstructured.Unstructured, namespace string) (*unstructured.Unstructured, error) {
+	if obj.GetNamespace() != "" {
+		namespace = obj.GetNamespace()
+	}
+	mapping, err := o.factory.MappingFor(obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: o.fieldManager,
+		Force:        &o.forceConflicts,
+	})
+}
+
+// describeApplyError renders apply conflicts as a table of contested fields
+// and the managers owning them; other errors are returned as-is.
+func describeApplyError(err error) string {
+	var statusErr *apierrors.StatusError
+	if !apierrors.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil {
+		return err.Error()
+	}
+
+	var conflicts []metav1.StatusCause
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, cause)
+		}
+	}
+	if len(conflicts) == 0 {
+		return err.Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d field conflict(s) with other managers\n", len(conflicts))
+	w := tabwriter.NewWriter(&b, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "  FIELD\tOWNED BY")
+	for _, c := range conflicts {
+		fmt.Fprintf(w, "  %s\t%s\n", c.Field, conflictManager(c.Message))
+	}
+	_ = w.Flush()
+	b.WriteString("  re-run with --force-conflicts to take ownership of these fields")
+	return b.String()
+}
+
+// conflictManager extracts the manager from a conflict cause message such as
+// `conflict with "kubectl-client-side-apply" using apps/v1`.
+func conflictManager(message string) string {
+	const prefix = "conflict with "
+	if !strings.HasPrefix(message, prefix) {
+		return message
+	}
+	return strings.TrimPrefix(message, prefix)
+}
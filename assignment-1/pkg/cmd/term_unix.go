@@ -0,0 +1,59 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// terminalSizeQueue reports the local terminal size to the remote TTY: once
+// at start and again on every SIGWINCH.
+type terminalSizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue(ctx context.Context, fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{sizes: make(chan remotecommand.TerminalSize, 1)}
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	winch <- syscall.SIGWINCH
+
+	go func() {
+		defer signal.Stop(winch)
+		defer close(q.sizes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-winch:
+				width, height, err := term.GetSize(fd)
+				if err != nil {
+					continue
+				}
+				select {
+				case q.sizes <- remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return q
+}
+
+// Next blocks until the terminal is resized. It returns nil once the queue
+// is closed, which ends the resize stream.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package cmd
+
+import (
+	"context"
+
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// terminalSizeQueue reports the terminal size once; Windows has no SIGWINCH
+// to learn about later resizes.
+type terminalSizeQueue struct {
+	size *remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue(_ context.Context, fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{}
+	if width, height, err := term.GetSize(fd); err == nil {
+		q.size = &remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+	}
+	return q
+}
+
+// Next returns the initial size once and nil afterwards.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size := q.size
+	q.size = nil
+	return size
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// Sort orders accepted by --sort-by.
+const (
+	sortByCPU    = "cpu"
+	sortByMemory = "memory"
+)
+
+// topOptions holds the flags shared by the top subcommands.
+type topOptions struct {
+	factory       *kube.Factory
+	allNamespaces bool
+	containers    bool
+	sortBy        string
+	selectors     selectorFlags
+}
+
+// usageRow is one line of top output: a node, a pod or a container.
+type usageRow struct {
+	namespace, pod, name string
+	cpu, memory          resource.Quantity
+	// allocatable is only known for nodes and used for the percentages.
+	allocatable corev1.ResourceList
+}
+
+func newTopCmd(f *kube.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show the CPU and memory usage of nodes or pods",
+		Long: `Top reads the resource usage collected by metrics-server from the
+metrics.k8s.io API. The values are averages over a short window (typically
+15s), not limits or requests.`,
+	}
+	cmd.AddCommand(newTopNodesCmd(f), newTopPodsCmd(f))
+	return cmd
+}
+
+func newTopNodesCmd(f *kube.Factory) *cobra.Command {
+	o := &topOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:     "nodes [name]",
+		Aliases: []string{"node", "no"},
+		Short:   "Show the usage of nodes, also relative to their allocatable resources",
+		Example: `  k8sctl top nodes
+  k8sctl top nodes --sort-by=memory -l node-role.kubernetes.io/worker`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			return o.runNodes(cmd.Context(), cmd.OutOrStdout(), name)
+		},
+	}
+	o.addFlags(cmd)
+
+	return cmd
+}
+
+func newTopPodsCmd(f *kube.Factory) *cobra.Command {
+	o := &topOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:     "pods [name]",
+		Aliases: []string{"pod", "po"},
+		Short:   "Show the usage of pods, optionally per container",
+		Example: `  k8sctl top pods -A --sort-by=cpu
+  k8sctl top pods -l app=web --containers`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			return o.runPods(cmd.Context(), cmd.OutOrStdout(), name)
+		},
+	}
+	o.addFlags(cmd)
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "Show pods across all namespaces")
+	cmd.Flags().BoolVar(&o.containers, "containers", false, "Show one line per container instead of per pod")
+
+	return cmd
+}
+
+func (o *topOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.sortBy, "sort-by", "", "Sort by cpu or memory usage, highest first; by name when empty")
+	o.selectors.addFlags(cmd)
+}
+
+func (o *topOptions) runNodes(ctx context.Context, out io.Writer, name string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	mc, err := o.factory.MetricsClient()
+	if err != nil {
+		return err
+	}
+	cs, err := o.factory.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	var metrics []metricsv1beta1.NodeMetrics
+	if name != "" {
+		m, err := mc.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return o.metricsError(err)
+		}
+		metrics = append(metrics, *m)
+	} else {
+		list, err := mc.MetricsV1beta1().NodeMetricses().List(ctx, o.selectors.listOptions())
+		if err != nil {
+			return o.metricsError(err)
+		}
+		metrics = list.Items
+	}
+	if len(metrics) == 0 {
+		fmt.Fprintln(out, "No resources found")
+		return nil
+	}
+
+	// The percentages relate usage to what the node offers to pods.
+	nodes, err := cs.CoreV1().Nodes().List(ctx, o.selectors.listOptions())
+	if err != nil {
+		return err
+	}
+	allocatable := map[string]corev1.ResourceList{}
+	for _, n := range nodes.Items {
+		allocatable[n.Name] = n.Status.Allocatable
+	}
+
+	rows := make([]usageRow, 0, len(metrics))
+	for _, m := range metrics {
+		rows = append(rows, usageRow{
+			name:        m.Name,
+			cpu:         m.Usage[corev1.ResourceCPU],
+			memory:      m.Usage[corev1.ResourceMemory],
+			allocatable: allocatable[m.Name],
+		})
+	}
+	o.sort(rows)
+
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.name,
+			formatCPU(r.cpu), percentOf(r.cpu, r.allocatable[corev1.ResourceCPU]),
+			formatMemory(r.memory), percentOf(r.memory, r.allocatable[corev1.ResourceMemory]))
+	}
+	return w.Flush()
+}
+
+func (o *topOptions) runPods(ctx context.Context, out io.Writer, name string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	mc, err := o.factory.MetricsClient()
+	if err != nil {
+		return err
+	}
+	namespace, err := resolveNamespace(o.factory, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+
+	var metrics []metricsv1beta1.PodMetrics
+	if name != "" {
+		m, err := mc.MetricsV1beta1().PodMetricses(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return o.metricsError(err)
+		}
+		metrics = append(metrics, *m)
+	} else {
+		list, err := mc.MetricsV1beta1().PodMetricses(namespace).List(ctx, o.selectors.listOptions())
+		if err != nil {
+			return o.metricsError(err)
+		}
+		metrics = list.Items
+	}
+	if len(metrics) == 0 {
+		fmt.Fprintln(out, "No resources found")
+		return nil
+	}
+
+	var rows []usageRow
+	for _, m := range metrics {
+		if o.containers {
+			for _, c := range m.Containers {
+				rows = append(rows, usageRow{
+					namespace: m.Namespace,
+					pod:       m.Name,
+					name:      c.Name,
+					cpu:       c.Usage[corev1.ResourceCPU],
+					memory:    c.Usage[corev1.ResourceMemory],
+				})
+			}
+			continue
+		}
+		row := usageRow{namespace: m.Namespace, name: m.Name}
+		for _, c := range m.Containers {
+			row.cpu.Add(c.Usage[corev1.ResourceCPU])
+			row.memory.Add(c.Usage[corev1.ResourceMemory])
+		}
+		rows = append(rows, row)
+	}
+	o.sort(rows)
+
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	header := "NAME\tCPU(cores)\tMEMORY(bytes)"
+	if o.containers {
+		header = "POD\t" + header
+	}
+	if o.allNamespaces {
+		header = "NAMESPACE\t" + header
+	}
+	fmt.Fprintln(w, header)
+	for _, r := range rows {
+		if o.allNamespaces {
+			fmt.Fprintf(w, "%s\t", r.namespace)
+		}
+		if o.containers {
+			fmt.Fprintf(w, "%s\t", r.pod)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.name, formatCPU(r.cpu), formatMemory(r.memory))
+	}
+	return w.Flush()
+}
+
+func (o *topOptions) validate() error {
+	if o.sortBy != "" && o.sortBy != sortByCPU && o.sortBy != sortByMemory {
+		return fmt.Errorf("unknown --sort-by %q, expected cpu or memory", o.sortBy)
+	}
+	return o.selectors.validate()
+}
+
+// sort orders the rows by the --sort-by usage, highest first. Equal values
+// and the default order are by namespace, pod and name.
+func (o *topOptions) sort(rows []usageRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		var cmp int
+		switch o.sortBy {
+		case sortByCPU:
+			cmp = b.cpu.Cmp(a.cpu)
+		case sortByMemory:
+			cmp = b.memory.Cmp(a.memory)
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+		if a.namespace != b.namespace {
+			return a.namespace < b.namespace
+		}
+		if a.pod != b.pod {
+			return a.pod < b.pod
+		}
+		return a.name < b.name
+	})
+}
+
+// metricsError adds a hint about metrics-server to a 404 caused by the
+// metrics.k8s.io API not being served.
+func (o *topOptions) metricsError(err error) error {
+	dc, derr := o.factory.DiscoveryClient()
+	if derr != nil {
+		return err
+	}
+	return metricsError(dc, err)
+}
+
+// metricsError explains the 404 returned when metrics-server is missing. A
+// 404 for a single object, such as an unknown node, is returned as it is.
+func metricsError(dc discovery.DiscoveryInterface, err error) error {
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	groups, derr := dc.ServerGroups()
+	if derr != nil {
+		return err
+	}
+	for _, g := range groups.Groups {
+		if g.Name != metricsv1beta1.GroupName {
+			continue
+		}
+		for _, v := range g.Versions {
+			if v.Version == metricsv1beta1.SchemeGroupVersion.Version {
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("metrics not available, is metrics-server installed and ready? %w", err)
+}
+
+// formatCPU renders CPU usage in millicores, e.g. 250m.
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+// formatMemory renders memory usage in mebibytes, e.g. 128Mi.
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
+
+// percentOf renders usage as a percentage of capacity.
+func percentOf(usage, capacity resource.Quantity) string {
+	if capacity.IsZero() {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%d%%", usage.MilliValue()*100/capacity.MilliValue())
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestMetricsError(t *testing.T) {
+	served := []*metav1.APIResourceList{{
+		GroupVersion: "metrics.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "nodes", Kind: "NodeMetrics"}, {Name: "pods", Namespaced: true, Kind: "PodMetrics"}},
+	}}
+	nodeNotFound := apierrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "nodes"}, "worker-9")
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "metrics.k8s.io", Resource: "nodes"}, "", errors.New("denied"))
+
+	tests := []struct {
+		name     string
+		served   []*metav1.APIResourceList
+		err      error
+		wantHint bool
+	}{
+		{name: "unknown object with metrics served", served: served, err: nodeNotFound},
+		{name: "metrics not served", err: nodeNotFound, wantHint: true},
+		{name: "other errors", err: forbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: tt.served}}
+			err := metricsError(dc, tt.err)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("metricsError() = %v, want it to wrap %v", err, tt.err)
+			}
+			if hint := strings.Contains(err.Error(), "metrics-server"); hint != tt.wantHint {
+				t.Errorf("metricsError() = %q, hint = %t, want %t", err, hint, tt.wantHint)
+			}
+		})
+	}
+}
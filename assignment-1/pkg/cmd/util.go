@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+)
+
+// resolveNamespace returns the namespace a command should operate in. An empty
+// namespace makes both the typed and the dynamic clients span all namespaces.
+func resolveNamespace(f *kube.Factory, allNamespaces bool) (string, error) {
+	if allNamespaces {
+		return metav1.NamespaceAll, nil
+	}
+	return f.DefaultNamespace()
+}
+
+// age renders the time since creation the same way kubectl does (e.g. 5d3h).
+func age(created metav1.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(created.Time))
+}
+
+// splitResourceName accepts either "<resource> <name>" or "<resource>/<name>"
+// as positional arguments and returns the two parts.
+func splitResourceName(args []string) (string, string, error) {
+	switch len(args) {
+	case 1:
+		if resource, name, ok := strings.Cut(args[0], "/"); ok && resource != "" && name != "" {
+			return resource, name, nil
+		}
+	case 2:
+		return args[0], args[1], nil
+	}
+	return "", "", fmt.Errorf("expected <resource>/<name> or <resource> <name>, got %q", strings.Join(args, " "))
+}
+
+// defaultChunkSize is the default page size of list calls, the same as
+// kubectl uses.
+const defaultChunkSize = 500
+
+// addChunkSizeFlag registers the --chunk-size flag of the list commands.
+func addChunkSizeFlag(cmd *cobra.Command, chunkSize *int64) {
+	cmd.Flags().Int64Var(chunkSize, "chunk-size", defaultChunkSize, "Fetch large lists in pages of this many objects, 0 disables paging")
+}
+
+// listPages lists the objects of client page by page with limit/continue and
+// calls fn for every page as soon as it arrives.
+func listPages(ctx context.Context, client dynamic.ResourceInterface, opts metav1.ListOptions, fn func(*unstructured.UnstructuredList) error) error {
+	for {
+		list, err := client.List(ctx, opts)
+		if err != nil {
+			return pagingError(err)
+		}
+		if err := fn(list); err != nil {
+			return err
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// pagingError explains the 410 Gone returned when a continue token expired,
+// which happens when the list is paged slower than etcd compacts.
+func pagingError(err error) error {
+	if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+		return fmt.Errorf("the list expired while paging, retry or raise --chunk-size: %w", err)
+	}
+	return err
+}
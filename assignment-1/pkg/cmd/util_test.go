@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// pagedLister serves List from fixed pages, page i being returned for the
+// continue token of page i-1. Other methods are not implemented.
+type pagedLister struct {
+	dynamic.ResourceInterface
+	pages [][]string
+	// errAt fails the List call for the page with this index.
+	errAt int
+	err   error
+	// calls records the options of every List call.
+	calls []metav1.ListOptions
+}
+
+func (l *pagedLister) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	l.calls = append(l.calls, opts)
+	page := len(l.calls) - 1
+	if l.err != nil && page == l.errAt {
+		return nil, l.err
+	}
+	if want := continueToken(page); opts.Continue != want {
+		return nil, apierrors.NewBadRequest("unexpected continue token " + opts.Continue)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	for _, name := range l.pages[page] {
+		item := unstructured.Unstructured{}
+		item.SetName(name)
+		list.Items = append(list.Items, item)
+	}
+	if page < len(l.pages)-1 {
+		list.SetContinue(continueToken(page + 1))
+	}
+	return list, nil
+}
+
+// continueToken is the token requesting the given page; the first page has
+// none.
+func continueToken(page int) string {
+	if page == 0 {
+		return ""
+	}
+	return fmt.Sprintf("page-%d", page)
+}
+
+func TestListPages(t *testing.T) {
+	errFn := errors.New("printer failed")
+
+	tests := []struct {
+		name      string
+		pages     [][]string
+		errAt     int
+		err       error
+		fnErrAt   int
+		want      []string
+		wantCalls int
+		wantErr   string
+		wantIs    func(error) bool
+	}{
+		{
+			name:      "single page",
+			pages:     [][]string{{"a", "b"}},
+			want:      []string{"a", "b"},
+			wantCalls: 1,
+		},
+		{
+			name:      "several pages",
+			pages:     [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+			want:      []string{"a", "b", "c", "d", "e"},
+			wantCalls: 3,
+		},
+		{
+			name:      "empty list",
+			pages:     [][]string{{}},
+			wantCalls: 1,
+		},
+		{
+			name:      "expired continue token",
+			pages:     [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+			errAt:     1,
+			err:       apierrors.NewResourceExpired("The provided continue parameter is too old"),
+			want:      []string{"a", "b"},
+			wantCalls: 2,
+			wantErr:   "the list expired while paging, retry or raise --chunk-size",
+			wantIs:    apierrors.IsResourceExpired,
+		},
+		{
+			name:      "other errors are returned as they are",
+			pages:     [][]string{{"a"}, {"b"}},
+			errAt:     1,
+			err:       apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied")),
+			want:      []string{"a"},
+			wantCalls: 2,
+			wantErr:   "forbidden",
+			wantIs:    apierrors.IsForbidden,
+		},
+		{
+			name:      "callback error stops paging",
+			pages:     [][]string{{"a"}, {"b"}, {"c"}},
+			fnErrAt:   2,
+			want:      []string{"a"},
+			wantCalls: 2,
+			wantErr:   errFn.Error(),
+			wantIs:    func(err error) bool { return errors.Is(err, errFn) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &pagedLister{pages: tt.pages, errAt: tt.errAt, err: tt.err}
+			var got []string
+			page := 0
+			err := listPages(context.Background(), lister, metav1.ListOptions{Limit: 2, LabelSelector: "app=web"}, func(list *unstructured.UnstructuredList) error {
+				page++
+				if page == tt.fnErrAt {
+					return errFn
+				}
+				for _, item := range list.Items {
+					got = append(got, item.GetName())
+				}
+				return nil
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("listPages() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if err != nil && !tt.wantIs(err) {
+					t.Errorf("listPages() error = %v does not wrap the original error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("listPages() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listPages() items = %q, want %q", got, tt.want)
+			}
+			if len(lister.calls) != tt.wantCalls {
+				t.Errorf("listPages() made %d List calls, want %d", len(lister.calls), tt.wantCalls)
+			}
+			// Every page keeps the limit and the selectors of the first call.
+			for i, opts := range lister.calls {
+				if opts.Limit != 2 || opts.LabelSelector != "app=web" {
+					t.Errorf("List call %d options = %+v, want limit 2 and the label selector", i, opts)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// waitOptions holds the flags of the wait command.
+type waitOptions struct {
+	factory *kube.Factory
+	forSpec string
+	timeout time.Duration
+}
+
+// waitCondition is the parsed --for flag.
+type waitCondition struct {
+	// delete waits for the object to disappear.
+	delete bool
+	// conditionType and conditionStatus select an entry of
+	// status.conditions.
+	conditionType, conditionStatus string
+	// path and value wait for a JSONPath expression; an empty value waits
+	// for the expression to return anything at all.
+	path    *jsonpath.JSONPath
+	value   string
+	display string
+}
+
+func newWaitCmd(f *kube.Factory) *cobra.Command {
+	o := &waitOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "wait (<resource>/<name>... | <resource> <name>...) --for=CONDITION",
+		Short: "Wait for objects to reach a condition or to be deleted",
+		Long: `Wait watches the objects until they satisfy --for:
+
+  delete                          the object is gone
+  condition=TYPE[=STATUS]         status.conditions has TYPE with STATUS
+                                  (default True); conditions that report an
+                                  observedGeneration older than the object's
+                                  generation are ignored as stale
+  jsonpath=EXPR[=VALUE]           the JSONPath expression yields VALUE, or
+                                  anything when VALUE is omitted
+
+Conditions work for any resource following the status.conditions
+convention, custom resources included. The watch is driven by a single-object
+informer (watchtools.UntilWithSync), which re-lists when the watch breaks.`,
+		Example: `  k8sctl wait pod/web-5d4c --for=condition=Ready --timeout=120s
+  k8sctl wait deploy web api --for=condition=Available
+  k8sctl wait pod/web-5d4c pod/api-7f9b --for=condition=Ready
+  k8sctl wait pod/web-5d4c --for=jsonpath='{.status.phase}'=Running
+  k8sctl wait pod/job-x7k2 --for=delete`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource, names, err := waitTargets(args)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd.Context(), cmd.OutOrStdout(), resource, names)
+		},
+	}
+	cmd.Flags().StringVar(&o.forSpec, "for", "", "Condition to wait for: delete, condition=TYPE[=STATUS] or jsonpath=EXPR[=VALUE]")
+	cmd.Flags().DurationVar(&o.timeout, "timeout", 30*time.Second, "How long to wait for all objects, 0 waits forever")
+	_ = cmd.MarkFlagRequired("for")
+
+	return cmd
+}
+
+func (o *waitOptions) run(ctx context.Context, out io.Writer, resource string, names []string) error {
+	cond, err := parseWaitCondition(o.forSpec)
+	if err != nil {
+		return err
+	}
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return err
+	}
+	namespace, err := o.factory.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	client, err := o.factory.ResourceClient(mapping, namespace)
+	if err != nil {
+		return err
+	}
+
+	// The timeout covers all objects together.
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	kind := printer.KindGroup(mapping.GroupVersionKind)
+	for _, name := range names {
+		err := waitFor(ctx, client, name, cond)
+		// Once the context ended, whatever the watch returned is a timeout
+		// (or an interrupt).
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out waiting for %s/%s to meet %s", kind, name, cond.display)
+		}
+		if err != nil {
+			return fmt.Errorf("%s/%s: %w", kind, name, err)
+		}
+		if cond.delete {
+			fmt.Fprintf(out, "%s/%s deleted\n", kind, name)
+		} else {
+			fmt.Fprintf(out, "%s/%s condition met\n", kind, name)
+		}
+	}
+	return nil
+}
+
+// waitTargets splits the arguments into the resource and the object names.
+// They are either "<resource> <name>..." or "<resource>/<name>...", the
+// latter all naming the same resource.
+func waitTargets(args []string) (string, []string, error) {
+	if !strings.Contains(args[0], "/") {
+		if len(args) < 2 {
+			return "", nil, fmt.Errorf("expected <resource>/<name>... or <resource> <name>..., got %q", args[0])
+		}
+		return args[0], args[1:], nil
+	}
+
+	var resource string
+	names := make([]string, 0, len(args))
+	for _, arg := range args {
+		r, name, err := splitResourceName([]string{arg})
+		if err != nil {
+			return "", nil, err
+		}
+		if resource != "" && r != resource {
+			return "", nil, fmt.Errorf("all objects must be of the same resource, got %q and %q", resource, r)
+		}
+		resource = r
+		names = append(names, name)
+	}
+	return resource, names, nil
+}
+
+// waitFor blocks until the named object satisfies cond.
+func waitFor(ctx context.Context, client dynamic.ResourceInterface, name string, cond *waitCondition) error {
+	initial, err := client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) && cond.delete {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	uid := initial.GetUID()
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return client.List(ctx, opts)
+		},
+		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return client.Watch(ctx, opts)
+		},
+	}
+
+	// The precondition sees the synced cache: the object may already be
+	// gone, or already satisfy the condition.
+	precondition := func(store cache.Store) (bool, error) {
+		items := store.List()
+		if len(items) == 0 {
+			if cond.delete {
+				return true, nil
+			}
+			return false, fmt.Errorf("object was deleted while waiting")
+		}
+		return cond.met(items[0].(*unstructured.Unstructured), uid)
+	}
+	_, err = watchtools.UntilWithSync(ctx, lw, &unstructured.Unstructured{}, precondition, func(ev watch.Event) (bool, error) {
+		switch ev.Type {
+		case watch.Deleted:
+			if cond.delete {
+				return true, nil
+			}
+			return false, fmt.Errorf("object was deleted while waiting")
+		case watch.Added, watch.Modified:
+			return cond.met(ev.Object.(*unstructured.Unstructured), uid)
+		}
+		return false, nil
+	})
+	return err
+}
+
+// met evaluates the condition against the current state of the object. uid
+// is the object the wait started with; for delete, a recreated object with a
+// new UID means the original one is gone.
+func (c *waitCondition) met(obj *unstructured.Unstructured, uid types.UID) (bool, error) {
+	switch {
+	case c.delete:
+		return obj.GetUID() != uid, nil
+	case c.path != nil:
+		return c.pathMatches(obj)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, nil
+	}
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]any)
+		if !ok || !strings.EqualFold(fmt.Sprint(condition["type"]), c.conditionType) {
+			continue
+		}
+		// A condition computed for an older generation says nothing about
+		// the current spec.
+		if observed, ok, _ := unstructured.NestedInt64(condition, "observedGeneration"); ok && observed < obj.GetGeneration() {
+			return false, nil
+		}
+		return strings.EqualFold(fmt.Sprint(condition["status"]), c.conditionStatus), nil
+	}
+	return false, nil
+}
+
+func (c *waitCondition) pathMatches(obj *unstructured.Unstructured) (bool, error) {
+	results, err := c.path.FindResults(obj.Object)
+	if err != nil {
+		return false, nil
+	}
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			var buf bytes.Buffer
+			if err := c.path.PrintResults(&buf, []reflect.Value{v}); err != nil {
+				return false, err
+			}
+			values = append(values, buf.String())
+		}
+	}
+	if c.value == "" {
+		return len(values) > 0, nil
+	}
+	if len(values) == 0 {
+		return false, nil
+	}
+	for _, v := range values {
+		if v != c.value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseWaitCondition parses the --for flag.
+func parseWaitCondition(spec string) (*waitCondition, error) {
+	kind, arg, _ := strings.Cut(spec, "=")
+	switch strings.ToLower(kind) {
+	case "delete":
+		return &waitCondition{delete: true, display: "delete"}, nil
+
+	case "condition":
+		conditionType, status, hasStatus := strings.Cut(arg, "=")
+		if conditionType == "" {
+			return nil, fmt.Errorf("--for=condition requires a condition type, e.g. condition=Ready")
+		}
+		if !hasStatus {
+			status = "True"
+		}
+		return &waitCondition{conditionType: conditionType, conditionStatus: status, display: spec}, nil
+
+	case "jsonpath":
+		// The expression may contain "=" itself, e.g. in filters, so the
+		// value is only split off after the closing brace.
+		expr, value := arg, ""
+		if strings.HasPrefix(arg, "{") {
+			end := strings.LastIndex(arg, "}")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated JSONPath expression %q", arg)
+			}
+			rest := arg[end+1:]
+			if rest != "" && !strings.HasPrefix(rest, "=") {
+				return nil, fmt.Errorf("unexpected %q after the JSONPath expression, expected =VALUE", rest)
+			}
+			expr, value = arg[:end+1], strings.TrimPrefix(rest, "=")
+		} else {
+			expr, value, _ = strings.Cut(arg, "=")
+			expr = "{" + expr + "}"
+		}
+		path := jsonpath.New("wait").AllowMissingKeys(true)
+		if err := path.Parse(expr); err != nil {
+			return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+		}
+		return &waitCondition{path: path, value: value, display: spec}, nil
+	}
+	return nil, fmt.Errorf("unknown --for %q, expected delete, condition=TYPE[=STATUS] or jsonpath=EXPR[=VALUE]", spec)
+}
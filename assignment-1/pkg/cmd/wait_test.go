@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    waitCondition
+		wantErr string
+	}{
+		{spec: "delete", want: waitCondition{delete: true}},
+		{spec: "condition=Ready", want: waitCondition{conditionType: "Ready", conditionStatus: "True"}},
+		{spec: "condition=Available=False", want: waitCondition{conditionType: "Available", conditionStatus: "False"}},
+		{spec: "jsonpath={.status.phase}=Running", want: waitCondition{value: "Running"}},
+		{spec: "jsonpath=.status.phase=Running", want: waitCondition{value: "Running"}},
+		{spec: "jsonpath={.status.loadBalancer.ingress}", want: waitCondition{}},
+		{spec: `jsonpath={.status.conditions[?(@.type=="Ready")].status}=True`, want: waitCondition{value: "True"}},
+		{spec: "condition", wantErr: "requires a condition type"},
+		{spec: "jsonpath={.status", wantErr: "unterminated JSONPath expression"},
+		{spec: "jsonpath={.status[}", wantErr: "invalid JSONPath"},
+		{spec: "jsonpath={.status.phase}Running", wantErr: `unexpected "Running" after the JSONPath expression`},
+		{spec: "ready", wantErr: `unknown --for "ready"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseWaitCondition(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseWaitCondition() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWaitCondition() error = %v", err)
+			}
+			if got.delete != tt.want.delete || got.conditionType != tt.want.conditionType ||
+				got.conditionStatus != tt.want.conditionStatus || got.value != tt.want.value {
+				t.Errorf("parseWaitCondition() = %+v, want %+v", *got, tt.want)
+			}
+			if hasPath := strings.HasPrefix(tt.spec, "jsonpath="); (got.path != nil) != hasPath {
+				t.Errorf("parseWaitCondition() path set = %t, want %t", got.path != nil, hasPath)
+			}
+		})
+	}
+}
+
+func TestWaitConditionMet(t *testing.T) {
+	pod := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "web", "uid": "1", "generation": int64(2)},
+		"status": map[string]any{
+			"phase": "Running",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True"},
+				map[string]any{"type": "Available", "status": "True", "observedGeneration": int64(1)},
+			},
+			"podIPs": []any{
+				map[string]any{"ip": "10.0.0.1"},
+				map[string]any{"ip": "fd00::1"},
+			},
+		},
+	}}
+
+	tests := []struct {
+		spec string
+		uid  types.UID
+		want bool
+	}{
+		{spec: "delete", uid: "1", want: false},
+		{spec: "delete", uid: "0", want: true},
+		{spec: "condition=Ready", want: true},
+		{spec: "condition=ready=true", want: true},
+		{spec: "condition=Ready=False", want: false},
+		{spec: "condition=Progressing", want: false},
+		{spec: "condition=Available", want: false},
+		{spec: "jsonpath={.status.phase}=Running", want: true},
+		{spec: "jsonpath={.status.phase}=Pending", want: false},
+		{spec: "jsonpath={.status.phase}", want: true},
+		{spec: "jsonpath={.status.hostIP}", want: false},
+		{spec: "jsonpath={.status.hostIP}=10.0.0.2", want: false},
+		{spec: "jsonpath={.status.podIPs[*].ip}=10.0.0.1", want: false},
+		{spec: `jsonpath={.status.conditions[?(@.type=="Ready")].status}=True`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			c, err := parseWaitCondition(tt.spec)
+			if err != nil {
+				t.Fatalf("parseWaitCondition() error = %v", err)
+			}
+			got, err := c.met(pod, tt.uid)
+			if err != nil {
+				t.Fatalf("met() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("met() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitTargets(t *testing.T) {
+	tests := []struct {
+		args         []string
+		wantResource string
+		wantNames    []string
+		wantErr      string
+	}{
+		{args: []string{"pod/web"}, wantResource: "pod", wantNames: []string{"web"}},
+		{args: []string{"pod/web", "pod/api"}, wantResource: "pod", wantNames: []string{"web", "api"}},
+		{args: []string{"deploy", "web", "api"}, wantResource: "deploy", wantNames: []string{"web", "api"}},
+		{args: []string{"pod/web", "deploy/api"}, wantErr: "all objects must be of the same resource"},
+		{args: []string{"pod/web", "api"}, wantErr: "<resource>/<name>"},
+		{args: []string{"pod"}, wantErr: "expected <resource>/<name>"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			resource, names, err := waitTargets(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("waitTargets() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitTargets() error = %v", err)
+			}
+			if resource != tt.wantResource || !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("waitTargets() = %q, %q, want %q, %q", resource, names, tt.wantResource, tt.wantNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/diff"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/kube"
+	"github.com/aryan-maurya-1998/k8s-dev-training/assignment-1/pkg/printer"
+)
+
+// Event types reported by the watch command. RESYNC is emitted when the
+// informer re-delivers an unchanged object from its cache on resync.
+const (
+	eventAdded    = "ADDED"
+	eventModified = "MODIFIED"
+	eventDeleted  = "DELETED"
+	eventResync   = "RESYNC"
+)
+
+// watchOptions holds the flags of the watch command.
+type watchOptions struct {
+	factory       *kube.Factory
+	allNamespaces bool
+	resync        time.Duration
+	watchOnly     bool
+	showResync    bool
+	jsonStream    bool
+	selectors     selectorFlags
+
+	// mu serialises output, handlers may be called from several goroutines.
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// watchEvent is the structured summary of a single informer notification,
+// printed as one line of text or as one JSON document per line.
+type watchEvent struct {
+	Time            time.Time `json:"time"`
+	Type            string    `json:"type"`
+	Kind            string    `json:"kind"`
+	Namespace       string    `json:"namespace,omitempty"`
+	Name            string    `json:"name"`
+	ResourceVersion string    `json:"resourceVersion,omitempty"`
+	// InitialList is set for the ADDED events produced by the initial list.
+	InitialList bool `json:"initialList,omitempty"`
+	// Changes lists the field paths that differ between old and new object,
+	// in the notation of the diff command.
+	Changes []string `json:"changes,omitempty"`
+}
+
+func newWatchCmd(f *kube.Factory) *cobra.Command {
+	o := &watchOptions{factory: f}
+
+	cmd := &cobra.Command{
+		Use:   "watch <resource>",
+		Short: "Watch changes to a resource through a shared informer",
+		Long: `Watch starts a shared informer for the resource, waits for its cache to sync
+and prints a summary of every add, update and delete notification.
+
+With --resync the informer periodically re-delivers every cached object to the
+update handler; those notifications carry an unchanged resourceVersion and are
+reported as RESYNC when --show-resync is set.`,
+		Example: `  k8sctl watch pods
+  k8sctl watch deployments.apps -A --watch-only
+  k8sctl watch configmaps --resync=30s --show-resync --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.out = cmd.OutOrStdout()
+			return o.run(cmd.Context(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "Watch objects across all namespaces")
+	cmd.Flags().DurationVar(&o.resync, "resync", 0, "Informer resync period, 0 disables resync")
+	cmd.Flags().BoolVar(&o.watchOnly, "watch-only", false, "Do not print the objects delivered by the initial list")
+	cmd.Flags().BoolVar(&o.showResync, "show-resync", false, "Print the notifications caused by a resync")
+	cmd.Flags().BoolVar(&o.jsonStream, "json", false, "Print every event as a JSON document on its own line")
+	o.selectors.addFlags(cmd)
+
+	return cmd
+}
+
+func (o *watchOptions) run(ctx context.Context, log io.Writer, resource string) error {
+	if err := o.selectors.validate(); err != nil {
+		return err
+	}
+	mapping, err := o.factory.ResolveResource(resource)
+	if err != nil {
+		return err
+	}
+	namespace, err := resolveNamespace(o.factory, o.allNamespaces)
+	if err != nil {
+		return err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	}
+	dc, err := o.factory.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	// The selectors are applied to both the initial list and the watch, so
+	// the informer only ever caches matching objects.
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dc, o.resync, namespace, func(opts *metav1.ListOptions) {
+		opts.LabelSelector = o.selectors.labelSelector
+		opts.FieldSelector = o.selectors.fieldSelector
+	})
+	informer := factory.ForResource(mapping.Resource).Informer()
+	kind := printer.KindGroup(mapping.GroupVersionKind)
+
+	// The detailed handler tells apart objects coming from the initial list
+	// from objects added afterwards.
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if isInInitialList && o.watchOnly {
+				return
+			}
+			ev := o.newEvent(eventAdded, kind, obj)
+			ev.InitialList = isInInitialList
+			o.print(ev)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldU, newU := oldObj.(*unstructured.Unstructured), newObj.(*unstructured.Unstructured)
+			// A resync re-delivers the cached object as-is.
+			if oldU.GetResourceVersion() == newU.GetResourceVersion() {
+				if o.showResync {
+					o.print(o.newEvent(eventResync, kind, newObj))
+				}
+				return
+			}
+			ev := o.newEvent(eventModified, kind, newObj)
+			ev.Changes = changedFields(oldU.Object, newU.Object)
+			o.print(ev)
+		},
+		DeleteFunc: func(obj interface{}) {
+			// When the watch missed the delete the informer hands us a
+			// tombstone carrying the last known state of the object.
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			o.print(o.newEvent(eventDeleted, kind, obj))
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+
+	start := time.Now()
+	for gvr, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			// Interrupted before the initial list completed.
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("cache for %s did not sync", gvr)
+		}
+	}
+	fmt.Fprintf(log, "cache synced for %s in %s, %d objects cached\n",
+		kind, time.Since(start).Round(time.Millisecond), len(informer.GetStore().ListKeys()))
+
+	<-ctx.Done()
+	return nil
+}
+
+func (o *watchOptions) newEvent(eventType, kind string, obj interface{}) watchEvent {
+	ev := watchEvent{Time: time.Now(), Type: eventType, Kind: kind}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		ev.Namespace = accessor.GetNamespace()
+		ev.Name = accessor.GetName()
+		ev.ResourceVersion = accessor.GetResourceVersion()
+	}
+	return ev
+}
+
+func (o *watchOptions) print(ev watchEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.jsonStream {
+		_ = json.NewEncoder(o.out).Encode(ev)
+		return
+	}
+
+	name := ev.Name
+	if ev.Namespace != "" {
+		name = ev.Namespace + "/" + name
+	}
+	line := fmt.Sprintf("%s %-8s %s/%s rv=%s", ev.Time.Format(time.TimeOnly), ev.Type, ev.Kind, name, ev.ResourceVersion)
+	if ev.InitialList {
+		line += " (initial list)"
+	}
+	if len(ev.Changes) > 0 {
+		line += " changed: " + strings.Join(ev.Changes, ", ")
+	}
+	fmt.Fprintln(o.out, line)
+}
+
+// ignoredFields are the fields that change on every write and would only add
+// noise to the change summary.
+var ignoredFields = []string{"metadata.resourceVersion", "metadata.managedFields", "metadata.generation"}
+
+// changedFields compares two objects and returns the paths of the fields that
+// differ, e.g. spec.template.spec.containers[name=web].image.
+func changedFields(oldObj, newObj map[string]interface{}) []string {
+	changes := diff.Compare(oldObj, newObj, ignoredFields...)
+	paths := make([]string, 0, len(changes))
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	return paths
+}
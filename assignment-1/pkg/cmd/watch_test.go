@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	oldObj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"resourceVersion": "1",
+			"generation":      int64(1),
+			"labels":          map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "web:1"},
+			}}},
+		},
+	}
+	newObj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"resourceVersion": "2",
+			"generation":      int64(2),
+			"labels":          map[string]interface{}{"app": "web", "app.kubernetes.io/version": "2"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "web:2"},
+			}}},
+		},
+	}
+
+	want := []string{
+		`metadata.labels["app.kubernetes.io/version"]`,
+		"spec.template.spec.containers[name=web].image",
+	}
+	if got := changedFields(oldObj, newObj); !reflect.DeepEqual(got, want) {
+		t.Errorf("changedFields() = %q, want %q", got, want)
+	}
+	if got := changedFields(oldObj, oldObj); len(got) != 0 {
+		t.Errorf("changedFields() of an unchanged object = %q, want none", got)
+	}
+}
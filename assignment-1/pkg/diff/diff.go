@@ -0,0 +1,224 @@
+// Package diff computes field level differences between two objects in their
+// unstructured form (nested map[string]any, []any and scalars), as produced
+// by decoding JSON or by unstructured.Unstructured.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Op is the kind of a change.
+type Op string
+
+// Change operations.
+const (
+	Added   Op = "+"
+	Removed Op = "-"
+	Changed Op = "~"
+)
+
+// Change is one differing field.
+type Change struct {
+	// Path locates the field, e.g. spec.template.spec.containers[name=web].image.
+	Path string
+	Op   Op
+	// Old and New are the values before and after; Old is nil for Added and
+	// New is nil for Removed.
+	Old, New any
+}
+
+// String renders the change as one line, e.g. `~ spec.replicas: 2 -> 3`.
+func (c Change) String() string {
+	switch c.Op {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", c.Path, render(c.New))
+	case Removed:
+		return fmt.Sprintf("- %s: %s", c.Path, render(c.Old))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Path, render(c.Old), render(c.New))
+}
+
+// Compare returns the changes turning from into to, ordered by path. Fields
+// whose path equals or starts with one of the ignored paths (e.g.
+// "metadata.managedFields" or "status") are skipped.
+//
+// Maps are compared key by key. Lists of objects that all carry a unique
+// name, such as containers, env or ports, are matched by name so that an
+// insertion does not report every following element as changed; other lists
+// are compared by index.
+func Compare(from, to any, ignore ...string) []Change {
+	var changes []Change
+	compare(&changes, "", from, to, ignore)
+	return changes
+}
+
+func compare(changes *[]Change, path string, from, to any, ignore []string) {
+	if ignored(path, ignore) || equality.Semantic.DeepEqual(from, to) {
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	if fromIsMap && toIsMap {
+		for _, key := range unionKeys(fromMap, toMap) {
+			sub := join(path, key)
+			fromVal, inFrom := fromMap[key]
+			toVal, inTo := toMap[key]
+			switch {
+			case !inFrom:
+				added(changes, sub, toVal, ignore)
+			case !inTo:
+				removed(changes, sub, fromVal, ignore)
+			default:
+				compare(changes, sub, fromVal, toVal, ignore)
+			}
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]any)
+	toList, toIsList := to.([]any)
+	if fromIsList && toIsList {
+		compareLists(changes, path, fromList, toList, ignore)
+		return
+	}
+
+	*changes = append(*changes, Change{Path: path, Op: Changed, Old: from, New: to})
+}
+
+func compareLists(changes *[]Change, path string, from, to []any, ignore []string) {
+	fromNames, fromNamed := namedItems(from)
+	toNames, toNamed := namedItems(to)
+	if fromNamed && toNamed {
+		for _, name := range unionKeys(fromNames, toNames) {
+			sub := fmt.Sprintf("%s[name=%s]", path, name)
+			fromVal, inFrom := fromNames[name]
+			toVal, inTo := toNames[name]
+			switch {
+			case !inFrom:
+				added(changes, sub, toVal, ignore)
+			case !inTo:
+				removed(changes, sub, fromVal, ignore)
+			default:
+				compare(changes, sub, fromVal, toVal, ignore)
+			}
+		}
+		return
+	}
+
+	for i := range max(len(from), len(to)) {
+		sub := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(from):
+			added(changes, sub, to[i], ignore)
+		case i >= len(to):
+			removed(changes, sub, from[i], ignore)
+		default:
+			compare(changes, sub, from[i], to[i], ignore)
+		}
+	}
+}
+
+// added reports a value that only exists in to. Non-empty objects are
+// compared against an empty one instead of being reported whole, so that
+// ignored fields inside them are skipped and every field gets its own line.
+func added(changes *[]Change, path string, v any, ignore []string) {
+	if m, ok := v.(map[string]any); ok && len(m) > 0 {
+		compare(changes, path, map[string]any{}, m, ignore)
+		return
+	}
+	if !ignored(path, ignore) {
+		*changes = append(*changes, Change{Path: path, Op: Added, New: v})
+	}
+}
+
+// removed is the counterpart of added for values that only exist in from.
+func removed(changes *[]Change, path string, v any, ignore []string) {
+	if m, ok := v.(map[string]any); ok && len(m) > 0 {
+		compare(changes, path, m, map[string]any{}, ignore)
+		return
+	}
+	if !ignored(path, ignore) {
+		*changes = append(*changes, Change{Path: path, Op: Removed, Old: v})
+	}
+}
+
+// namedItems indexes a list of objects by their name field. It reports false
+// when an item is no object, has no name or shares its name with another.
+func namedItems(list []any) (map[string]any, bool) {
+	if len(list) == 0 {
+		return map[string]any{}, true
+	}
+	items := make(map[string]any, len(list))
+	for _, item := range list {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := obj["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		if _, dup := items[name]; dup {
+			return nil, false
+		}
+		items[name] = item
+	}
+	return items, true
+}
+
+func ignored(path string, ignore []string) bool {
+	for _, prefix := range ignore {
+		if path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// plainKey matches map keys that can be written as .key in a path.
+var plainKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// join appends key to path, quoting keys such as annotation names that
+// contain dots or slashes: metadata.labels["app.kubernetes.io/name"].
+func join(path, key string) string {
+	if !plainKey.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// unionKeys returns the sorted set of keys present in either map.
+func unionKeys(a, b map[string]any) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// render prints a value as compact JSON.
+func render(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
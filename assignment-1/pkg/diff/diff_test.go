@@ -0,0 +1,171 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to any
+		ignore   []string
+		want     []string
+	}{
+		{
+			name: "equal",
+			from: map[string]any{"spec": map[string]any{"replicas": 2.0}},
+			to:   map[string]any{"spec": map[string]any{"replicas": 2.0}},
+			want: []string{},
+		},
+		{
+			name: "changed, added and removed keys",
+			from: map[string]any{"spec": map[string]any{"replicas": 2.0, "paused": true}},
+			to:   map[string]any{"spec": map[string]any{"replicas": 3.0, "minReadySeconds": 5.0}},
+			want: []string{
+				`+ spec.minReadySeconds: 5`,
+				`- spec.paused: true`,
+				`~ spec.replicas: 2 -> 3`,
+			},
+		},
+		{
+			name: "named list matched by name",
+			from: map[string]any{"containers": []any{
+				map[string]any{"name": "web", "image": "web:1"},
+				map[string]any{"name": "log", "image": "log:1"},
+			}},
+			to: map[string]any{"containers": []any{
+				map[string]any{"name": "init", "image": "init:1"},
+				map[string]any{"name": "web", "image": "web:2"},
+				map[string]any{"name": "log", "image": "log:1"},
+			}},
+			want: []string{
+				`+ containers[name=init].image: "init:1"`,
+				`+ containers[name=init].name: "init"`,
+				`~ containers[name=web].image: "web:1" -> "web:2"`,
+			},
+		},
+		{
+			name: "named list with removed item",
+			from: map[string]any{"ports": []any{
+				map[string]any{"name": "http", "port": 80.0},
+				map[string]any{"name": "https", "port": 443.0},
+			}},
+			to: map[string]any{"ports": []any{
+				map[string]any{"name": "https", "port": 443.0},
+			}},
+			want: []string{
+				`- ports[name=http].name: "http"`,
+				`- ports[name=http].port: 80`,
+			},
+		},
+		{
+			name: "list without unique names compared by index",
+			from: map[string]any{"args": []any{"--v=1", "--port=80"}},
+			to:   map[string]any{"args": []any{"--v=2", "--port=80", "--debug"}},
+			want: []string{
+				`~ args[0]: "--v=1" -> "--v=2"`,
+				`+ args[2]: "--debug"`,
+			},
+		},
+		{
+			name: "quoted keys",
+			from: map[string]any{"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "web"}}},
+			to:   map[string]any{"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "api"}}},
+			want: []string{
+				`~ metadata.labels["app.kubernetes.io/name"]: "web" -> "api"`,
+			},
+		},
+		{
+			name: "quoted key at the top level",
+			from: map[string]any{},
+			to:   map[string]any{"a.b": "c"},
+			want: []string{
+				`+ ["a.b"]: "c"`,
+			},
+		},
+		{
+			name: "ignore prefixes",
+			from: map[string]any{
+				"metadata": map[string]any{"resourceVersion": "1", "generation": 1.0, "name": "web"},
+				"status":   map[string]any{"replicas": 1.0},
+			},
+			to: map[string]any{
+				"metadata": map[string]any{"resourceVersion": "2", "generation": 2.0, "name": "web"},
+				"status":   map[string]any{"replicas": 3.0},
+			},
+			ignore: []string{"status", "metadata.resourceVersion"},
+			want: []string{
+				`~ metadata.generation: 1 -> 2`,
+			},
+		},
+		{
+			name:   "ignore prefix does not match a longer key",
+			from:   map[string]any{"status": "a", "statusDetail": "a"},
+			to:     map[string]any{"status": "b", "statusDetail": "b"},
+			ignore: []string{"status"},
+			want: []string{
+				`~ statusDetail: "a" -> "b"`,
+			},
+		},
+		{
+			name:   "ignore prefix on list items",
+			from:   map[string]any{"containers": []any{map[string]any{"name": "web", "image": "web:1"}}},
+			to:     map[string]any{"containers": []any{map[string]any{"name": "web", "image": "web:2"}}},
+			ignore: []string{"containers"},
+			want:   []string{},
+		},
+		{
+			name: "type change",
+			from: map[string]any{"value": "1"},
+			to:   map[string]any{"value": map[string]any{"n": 1.0}},
+			want: []string{
+				`~ value: "1" -> {"n":1}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lines(t, Compare(tt.from, tt.to, tt.ignore...))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compare() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareNewObject(t *testing.T) {
+	merged := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":              "settings",
+			"namespace":         "default",
+			"uid":               "0b7c",
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"managedFields":     []any{map[string]any{"manager": "k8sctl"}},
+		},
+		"data": map[string]any{"debug": "true"},
+	}
+	ignore := []string{"metadata.managedFields", "metadata.uid", "metadata.creationTimestamp"}
+
+	want := []string{
+		`+ apiVersion: "v1"`,
+		`+ data.debug: "true"`,
+		`+ kind: "ConfigMap"`,
+		`+ metadata.name: "settings"`,
+		`+ metadata.namespace: "default"`,
+	}
+	if got := lines(t, Compare(map[string]any{}, merged, ignore...)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func lines(t *testing.T, changes []Change) []string {
+	t.Helper()
+	out := make([]string, 0, len(changes))
+	for _, c := range changes {
+		out = append(out, c.String())
+	}
+	return out
+}
@@ -0,0 +1,230 @@
+// Package kube builds the Kubernetes clients used by the k8sctl commands.
+//
+// All commands share one Factory so the kubeconfig is only parsed once and
+// every client talks to the same cluster/context selected on the command line.
+package kube
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/tools/clientcmd"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// Factory lazily creates clients from the kubeconfig and context selected by
+// the user. When no kubeconfig can be found it falls back to the in-cluster
+// config, so the same binary works from a laptop and from inside a pod.
+type Factory struct {
+	// Kubeconfig is an explicit path to a kubeconfig file. When empty the
+	// usual KUBECONFIG / ~/.kube/config loading rules are applied.
+	Kubeconfig string
+	// Context overrides the current-context of the kubeconfig.
+	Context string
+	// Namespace overrides the namespace of the selected context.
+	Namespace string
+
+	once       sync.Once
+	clientCfg  clientcmd.ClientConfig
+	restConfig *rest.Config
+	configErr  error
+
+	clientsetOnce sync.Once
+	clientset     kubernetes.Interface
+	clientsetErr  error
+
+	dynamicOnce sync.Once
+	dynamic     dynamic.Interface
+	dynamicErr  error
+
+	discoveryOnce sync.Once
+	discovery     discovery.CachedDiscoveryInterface
+	discoveryErr  error
+
+	mapperOnce sync.Once
+	mapper     meta.RESTMapper
+
+	scaleOnce sync.Once
+	scale     scale.ScalesGetter
+	scaleErr  error
+
+	metricsOnce sync.Once
+	metrics     metricsclient.Interface
+	metricsErr  error
+}
+
+// loadConfig resolves the kubeconfig only once, on first use, so that flag
+// values are already parsed by the time we read them.
+func (f *Factory) loadConfig() {
+	f.once.Do(func() {
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		rules.ExplicitPath = f.Kubeconfig
+
+		overrides := &clientcmd.ConfigOverrides{
+			CurrentContext: f.Context,
+		}
+		overrides.Context.Namespace = f.Namespace
+
+		// NewNonInteractiveDeferredLoadingClientConfig falls back to the
+		// in-cluster config when no kubeconfig is available.
+		f.clientCfg = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+		f.restConfig, f.configErr = f.clientCfg.ClientConfig()
+	})
+}
+
+// RESTConfig returns the rest.Config for the selected cluster and context.
+func (f *Factory) RESTConfig() (*rest.Config, error) {
+	f.loadConfig()
+	if f.configErr != nil {
+		return nil, f.configErr
+	}
+	// Hand out a copy so callers can tweak it without affecting other clients.
+	return rest.CopyConfig(f.restConfig), nil
+}
+
+// DefaultNamespace returns the namespace that commands should use when the
+// user did not ask for all namespaces: the --namespace flag, the namespace of
+// the selected context, or "default".
+func (f *Factory) DefaultNamespace() (string, error) {
+	f.loadConfig()
+	if f.configErr != nil {
+		return "", f.configErr
+	}
+	ns, _, err := f.clientCfg.Namespace()
+	return ns, err
+}
+
+// ClientSet returns the typed client-go clientset.
+func (f *Factory) ClientSet() (kubernetes.Interface, error) {
+	f.clientsetOnce.Do(func() {
+		cfg, err := f.RESTConfig()
+		if err != nil {
+			f.clientsetErr = err
+			return
+		}
+		f.clientset, f.clientsetErr = kubernetes.NewForConfig(cfg)
+	})
+	return f.clientset, f.clientsetErr
+}
+
+// DynamicClient returns the dynamic client, which works with any resource as
+// unstructured.Unstructured objects.
+func (f *Factory) DynamicClient() (dynamic.Interface, error) {
+	f.dynamicOnce.Do(func() {
+		cfg, err := f.RESTConfig()
+		if err != nil {
+			f.dynamicErr = err
+			return
+		}
+		f.dynamic, f.dynamicErr = dynamic.NewForConfig(cfg)
+	})
+	return f.dynamic, f.dynamicErr
+}
+
+// DiscoveryClient returns a discovery client whose results are cached in
+// memory for the lifetime of the process.
+func (f *Factory) DiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	f.discoveryOnce.Do(func() {
+		cfg, err := f.RESTConfig()
+		if err != nil {
+			f.discoveryErr = err
+			return
+		}
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			f.discoveryErr = err
+			return
+		}
+		f.discovery = memory.NewMemCacheClient(dc)
+	})
+	return f.discovery, f.discoveryErr
+}
+
+// RESTMapper returns a discovery backed RESTMapper which also understands the
+// short names (po, svc, deploy, ...) published by the API server.
+func (f *Factory) RESTMapper() (meta.RESTMapper, error) {
+	dc, err := f.DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	f.mapperOnce.Do(func() {
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(dc)
+		f.mapper = restmapper.NewShortcutExpander(mapper, dc, nil)
+	})
+	return f.mapper, nil
+}
+
+// ScaleClient returns a client for the scale subresource of any resource that
+// serves one, built-in or custom. Discovery tells it which group/version the
+// autoscaling/v1 Scale object of every resource is served in.
+func (f *Factory) ScaleClient() (scale.ScalesGetter, error) {
+	f.scaleOnce.Do(func() {
+		cfg, err := f.RESTConfig()
+		if err != nil {
+			f.scaleErr = err
+			return
+		}
+		dc, err := f.DiscoveryClient()
+		if err != nil {
+			f.scaleErr = err
+			return
+		}
+		mapper, err := f.RESTMapper()
+		if err != nil {
+			f.scaleErr = err
+			return
+		}
+		f.scale, f.scaleErr = scale.NewForConfig(cfg, mapper, dynamic.LegacyAPIPathResolverFunc, scale.NewDiscoveryScaleKindResolver(dc))
+	})
+	return f.scale, f.scaleErr
+}
+
+// MetricsClient returns the typed client of the metrics.k8s.io API served by
+// metrics-server.
+func (f *Factory) MetricsClient() (metricsclient.Interface, error) {
+	f.metricsOnce.Do(func() {
+		cfg, err := f.RESTConfig()
+		if err != nil {
+			f.metricsErr = err
+			return
+		}
+		f.metrics, f.metricsErr = metricsclient.NewForConfig(cfg)
+	})
+	return f.metrics, f.metricsErr
+}
+
+// ForContext returns a new Factory using the same kubeconfig and namespace
+// flags but targeting another context. It is used to fan a command out to
+// several clusters.
+func (f *Factory) ForContext(context string) *Factory {
+	return &Factory{
+		Kubeconfig: f.Kubeconfig,
+		Context:    context,
+		Namespace:  f.Namespace,
+	}
+}
+
+// Contexts returns the names of all contexts of the kubeconfig, sorted.
+func (f *Factory) Contexts() ([]string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = f.Kubeconfig
+	config, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// ResolveResource turns a single user supplied resource argument into a
+// RESTMapping. The following forms are accepted:
+//
+//	group/version/resource   e.g. apps/v1/deployments
+//	version/resource         e.g. v1/pods (core group)
+//	resource[.version][.group] e.g. deploy, deployments.apps, cronjobs.v1.batch
+//
+// Short names such as "po" or "svc" are expanded through discovery.
+func (f *Factory) ResolveResource(arg string) (*meta.RESTMapping, error) {
+	mapper, err := f.RESTMapper()
+	if err != nil {
+		return nil, err
+	}
+
+	gvr, err := f.resourceFor(mapper, arg)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// ResolveResources behaves like ResolveResource but additionally understands
+// discovery categories (e.g. "all"), which expand to several resources.
+func (f *Factory) ResolveResources(arg string) ([]*meta.RESTMapping, error) {
+	dc, err := f.DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Categories never contain a "." or "/", so only plain words are checked.
+	if !strings.ContainsAny(arg, "./") {
+		categories := restmapper.NewDiscoveryCategoryExpander(dc)
+		if grs, ok := categories.Expand(arg); ok && len(grs) > 0 {
+			mappings := make([]*meta.RESTMapping, 0, len(grs))
+			for _, gr := range grs {
+				mapping, err := f.ResolveResource(gr.Resource + "." + gr.Group)
+				if err != nil {
+					return nil, err
+				}
+				mappings = append(mappings, mapping)
+			}
+			return mappings, nil
+		}
+	}
+
+	mapping, err := f.ResolveResource(arg)
+	if err != nil {
+		return nil, err
+	}
+	return []*meta.RESTMapping{mapping}, nil
+}
+
+// resourceFor parses arg into a fully qualified GroupVersionResource.
+func (f *Factory) resourceFor(mapper meta.RESTMapper, arg string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(arg, "/")
+	switch len(parts) {
+	case 3:
+		gvr := schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}
+		return mapper.ResourceFor(gvr)
+	case 2:
+		gvr := schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}
+		return mapper.ResourceFor(gvr)
+	case 1:
+	default:
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid resource %q, expected group/version/resource", arg)
+	}
+
+	// resource.version.group is tried first, falling back to resource.group.
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(arg))
+	if fullySpecified != nil {
+		if gvr, err := mapper.ResourceFor(*fullySpecified); err == nil {
+			return gvr, nil
+		}
+	}
+	return mapper.ResourceFor(groupResource.WithVersion(""))
+}
+
+// MappingFor resolves the RESTMapping of an object's apiVersion and kind, as
+// found in a manifest.
+func (f *Factory) MappingFor(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapper, err := f.RESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// ResourceClient returns a dynamic client for the mapping. Namespaced
+// resources are scoped to namespace (all namespaces when empty) while cluster
+// scoped resources ignore it.
+func (f *Factory) ResourceClient(mapping *meta.RESTMapping, namespace string) (dynamic.ResourceInterface, error) {
+	dc, err := f.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dc.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return dc.Resource(mapping.Resource), nil
+}
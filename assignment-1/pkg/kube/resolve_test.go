@@ -0,0 +1,103 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/restmapper"
+)
+
+func TestResourceFor(t *testing.T) {
+	groups := []*restmapper.APIGroupResources{
+		{
+			Group: metav1.APIGroup{
+				Versions:         []metav1.GroupVersionForDiscovery{{Version: "v1"}},
+				PreferredVersion: metav1.GroupVersionForDiscovery{Version: "v1"},
+			},
+			VersionedResources: map[string][]metav1.APIResource{
+				"v1": {{Name: "pods", Kind: "Pod", Namespaced: true}},
+			},
+		},
+		{
+			Group: metav1.APIGroup{
+				Name:             "apps",
+				Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "apps/v1", Version: "v1"}},
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "apps/v1", Version: "v1"},
+			},
+			VersionedResources: map[string][]metav1.APIResource{
+				"v1": {{Name: "deployments", Kind: "Deployment", Namespaced: true}},
+			},
+		},
+		{
+			Group: metav1.APIGroup{
+				Name: "batch",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "batch/v1", Version: "v1"},
+					{GroupVersion: "batch/v1beta1", Version: "v1beta1"},
+				},
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "batch/v1", Version: "v1"},
+			},
+			VersionedResources: map[string][]metav1.APIResource{
+				"v1":      {{Name: "cronjobs", Kind: "CronJob", Namespaced: true}},
+				"v1beta1": {{Name: "cronjobs", Kind: "CronJob", Namespaced: true}},
+			},
+		},
+		{
+			Group: metav1.APIGroup{
+				Name:             "networking.k8s.io",
+				Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "networking.k8s.io/v1", Version: "v1"}},
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "networking.k8s.io/v1", Version: "v1"},
+			},
+			VersionedResources: map[string][]metav1.APIResource{
+				"v1": {{Name: "ingresses", Kind: "Ingress", Namespaced: true}},
+			},
+		},
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+
+	tests := []struct {
+		arg     string
+		want    schema.GroupVersionResource
+		wantErr string
+	}{
+		{arg: "pods", want: schema.GroupVersionResource{Version: "v1", Resource: "pods"}},
+		{arg: "pod", want: schema.GroupVersionResource{Version: "v1", Resource: "pods"}},
+		{arg: "deployments", want: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{arg: "deployments.apps", want: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{arg: "Deployment.Apps", want: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{arg: "deployments.v1.apps", want: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{arg: "cronjobs.batch", want: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}},
+		{arg: "cronjobs.v1beta1.batch", want: schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"}},
+		// The group has a "." of its own, so resource.version.group does not
+		// match and resource.group is used instead.
+		{arg: "ingresses.networking.k8s.io", want: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}},
+		{arg: "ingresses.v1.networking.k8s.io", want: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}},
+		{arg: "apps/v1/deployments", want: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{arg: "batch/v1beta1/cronjobs", want: schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"}},
+		{arg: "v1/pods", want: schema.GroupVersionResource{Version: "v1", Resource: "pods"}},
+		{arg: "widgets", wantErr: "widgets"},
+		{arg: "deployments.batch", wantErr: "deployments"},
+		{arg: "apps/v2/deployments", wantErr: "deployments"},
+		{arg: "apps/v1/deployments/web", wantErr: `invalid resource "apps/v1/deployments/web"`},
+	}
+	f := &Factory{}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := f.resourceFor(mapper, tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resourceFor() = %v, error = %v, want it to contain %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resourceFor() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resourceFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package manifest reads Kubernetes objects from YAML or JSON manifests.
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Object is a decoded manifest document together with where it came from,
+// so results and errors can point the user back to the source.
+type Object struct {
+	*unstructured.Unstructured
+	// Source is the file name ("-" for stdin) and the document index in it.
+	Source string
+}
+
+// ReadPaths decodes every document of the given paths. A path can be a
+// file, a directory (its *.yaml, *.yml and *.json files are read, not
+// recursively) or "-" for stdin.
+func ReadPaths(paths []string, stdin io.Reader) ([]Object, error) {
+	var objects []Object
+	for _, path := range paths {
+		if path == "-" {
+			objs, err := Decode(stdin, "-")
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, objs...)
+			continue
+		}
+
+		files, err := expand(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			objs, err := readFile(file)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, objs...)
+		}
+	}
+	return objects, nil
+}
+
+// Decode splits a multi-document YAML (or JSON) stream and decodes every
+// non-empty document. List kinds (v1/List, ConfigMapList, ...) are flattened
+// into their items.
+func Decode(r io.Reader, source string) ([]Object, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+
+	var objects []Object
+	for doc := 1; ; doc++ {
+		raw, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: reading document %d: %w", source, doc, err)
+		}
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := utilyaml.Unmarshal(raw, &obj.Object); err != nil {
+			return nil, fmt.Errorf("%s: decoding document %d: %w", source, doc, err)
+		}
+		// Documents holding only comments decode to nothing.
+		if len(obj.Object) == 0 {
+			continue
+		}
+		docSource := fmt.Sprintf("%s#%d", source, doc)
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("%s: apiVersion and kind are required", docSource)
+		}
+
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				objects = append(objects, Object{Unstructured: item.(*unstructured.Unstructured), Source: docSource})
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", docSource, err)
+			}
+			continue
+		}
+		objects = append(objects, Object{Unstructured: obj, Source: docSource})
+	}
+}
+
+func readFile(file string) ([]Object, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f, file)
+}
+
+// expand returns the manifest files a path refers to.
+func expand(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	return files, nil
+}
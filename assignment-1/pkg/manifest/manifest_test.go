@@ -0,0 +1,155 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "multiple documents",
+			input: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`,
+			want: []string{"app.yaml#1 ConfigMap/settings", "app.yaml#2 Deployment/web"},
+		},
+		{
+			name: "empty and comment-only documents",
+			input: `---
+# shared settings
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+`,
+			want: []string{"app.yaml#2 ConfigMap/settings"},
+		},
+		{
+			name:  "json",
+			input: `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "web"}}`,
+			want:  []string{"app.yaml#1 Service/web"},
+		},
+		{
+			name: "list flattened into its items",
+			input: `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: b
+`,
+			want: []string{"app.yaml#1 ConfigMap/a", "app.yaml#1 Secret/b"},
+		},
+		{
+			name:  "empty stream",
+			input: "",
+			want:  nil,
+		},
+		{
+			name: "missing kind",
+			input: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: v1
+metadata:
+  name: web
+`,
+			wantErr: "app.yaml#2: apiVersion and kind are required",
+		},
+		{
+			name: "missing apiVersion",
+			input: `kind: ConfigMap
+metadata:
+  name: settings
+`,
+			wantErr: "app.yaml#1: apiVersion and kind are required",
+		},
+		{
+			name:    "invalid document",
+			input:   "kind: [",
+			wantErr: "app.yaml: decoding document 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := Decode(strings.NewReader(tt.input), "app.yaml")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Decode() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got := describe(objs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPaths(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.yaml":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n",
+		"b.json":    `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}`,
+		"notes.txt": "not a manifest",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stdin := strings.NewReader("apiVersion: v1\nkind: Secret\nmetadata:\n  name: c\n")
+
+	objs, err := ReadPaths([]string{dir, "-"}, stdin)
+	if err != nil {
+		t.Fatalf("ReadPaths() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "a.yaml") + "#1 ConfigMap/a",
+		filepath.Join(dir, "b.json") + "#1 ConfigMap/b",
+		"-#1 Secret/c",
+	}
+	if got := describe(objs); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadPaths() = %q, want %q", got, want)
+	}
+
+	if _, err := ReadPaths([]string{filepath.Join(dir, "missing.yaml")}, nil); err == nil {
+		t.Error("ReadPaths() of a missing file succeeded")
+	}
+}
+
+func describe(objs []Object) []string {
+	var out []string
+	for _, obj := range objs {
+		out = append(out, obj.Source+" "+obj.GetKind()+"/"+obj.GetName())
+	}
+	return out
+}
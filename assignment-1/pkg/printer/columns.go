@@ -0,0 +1,130 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// customColumn is a column of -o custom-columns=HEADER:.json.path,...
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// customColumnsPrinter prints the columns given on the command line, each
+// cell being a JSONPath expression evaluated against the object.
+type customColumnsPrinter struct {
+	out     io.Writer
+	leading []Column
+	columns []customColumn
+	count   int
+}
+
+// newCustomColumnsPrinter parses a spec such as
+// "NAME:.metadata.name,IMAGE:.spec.containers[*].image". The leading columns
+// are printed before the parsed ones.
+func newCustomColumnsPrinter(out io.Writer, spec string, leading []Column) (*customColumnsPrinter, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format requires a spec, e.g. custom-columns=NAME:.metadata.name")
+	}
+
+	p := &customColumnsPrinter{out: out, leading: leading}
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, ok := strings.Cut(part, ":")
+		if !ok || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:JSONPATH", part)
+		}
+		path, err := parseJSONPath(header, expr)
+		if err != nil {
+			return nil, err
+		}
+		p.columns = append(p.columns, customColumn{header: header, path: path})
+	}
+	return p, nil
+}
+
+// parseJSONPath accepts both ".metadata.name" and "{.metadata.name}". Missing
+// fields render as <none> rather than failing the whole output.
+func parseJSONPath(name, expr string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	path := jsonpath.New(name).AllowMissingKeys(true)
+	if err := path.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+	}
+	return path, nil
+}
+
+func (p *customColumnsPrinter) PrintObjects(objs []runtime.Object) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 3, ' ', 0)
+	if p.count == 0 {
+		headers := make([]string, 0, len(p.leading)+len(p.columns))
+		for _, c := range p.leading {
+			headers = append(headers, c.Header)
+		}
+		for _, c := range p.columns {
+			headers = append(headers, c.header)
+		}
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		cells := make([]string, 0, len(p.leading)+len(p.columns))
+		for _, c := range p.leading {
+			cells = append(cells, c.Value(obj))
+		}
+		for _, c := range p.columns {
+			cell, err := evalColumn(c.path, content)
+			if err != nil {
+				return err
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	p.count += len(objs)
+	return w.Flush()
+}
+
+// evalColumn evaluates the JSONPath, joining multiple results with commas.
+func evalColumn(path *jsonpath.JSONPath, content map[string]interface{}) (string, error) {
+	results, err := path.FindResults(content)
+	if err != nil {
+		return "", err
+	}
+
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			var buf bytes.Buffer
+			if err := path.PrintResults(&buf, []reflect.Value{v}); err != nil {
+				return "", err
+			}
+			values = append(values, buf.String())
+		}
+	}
+	if len(values) == 0 {
+		return "<none>", nil
+	}
+	return strings.Join(values, ","), nil
+}
+
+func (p *customColumnsPrinter) Flush() error { return nil }
+
+func (p *customColumnsPrinter) Count() int { return p.count }
@@ -0,0 +1,122 @@
+package printer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// csvPrinter prints the table columns as CSV or TSV for spreadsheets and
+// reporting jobs. Unlike the table it includes the wide columns, and the
+// cells are not padded.
+type csvPrinter struct {
+	w       *csv.Writer
+	columns []Column
+	count   int
+}
+
+// newCSVPrinter returns a CSV printer, or a TSV printer when sep is a tab.
+// spec optionally selects and orders the columns by header, e.g.
+// "NAMESPACE,NAME,IMAGES"; by default every column is printed.
+func newCSVPrinter(out io.Writer, opts Options, spec string, sep rune) (*csvPrinter, error) {
+	// NAMESPACE can always be selected, even when the table would omit it.
+	var available []Column
+	available = append(available, opts.Leading...)
+	namespace := Column{Header: "NAMESPACE", Value: func(obj runtime.Object) string {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return ""
+		}
+		return accessor.GetNamespace()
+	}}
+	if opts.WithNamespace {
+		available = append(available, namespace)
+	}
+	if !opts.WithoutName {
+		name := func(obj runtime.Object) string {
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				return ""
+			}
+			return accessor.GetName()
+		}
+		if opts.WithKind {
+			name = KindName
+		}
+		available = append(available, Column{Header: "NAME", Value: name})
+	}
+	available = append(available, opts.Columns...)
+
+	w := csv.NewWriter(out)
+	w.Comma = sep
+	p := &csvPrinter{w: w}
+	if spec == "" {
+		p.columns = available
+		return p, nil
+	}
+
+	for _, header := range strings.Split(spec, ",") {
+		column, ok := findColumn(available, header)
+		if !ok && strings.EqualFold(header, namespace.Header) {
+			column, ok = namespace, true
+		}
+		if !ok {
+			headers := make([]string, 0, len(available))
+			for _, c := range available {
+				headers = append(headers, c.Header)
+			}
+			return nil, fmt.Errorf("unknown column %q, available columns: %s", header, strings.Join(headers, ","))
+		}
+		p.columns = append(p.columns, column)
+	}
+	return p, nil
+}
+
+// findColumn looks a column up by its header, ignoring case.
+func findColumn(columns []Column, header string) (Column, bool) {
+	for _, c := range columns {
+		if strings.EqualFold(c.Header, strings.TrimSpace(header)) {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+func (p *csvPrinter) PrintObjects(objs []runtime.Object) error {
+	if len(objs) == 0 {
+		return nil
+	}
+	if p.count == 0 {
+		headers := make([]string, 0, len(p.columns))
+		for _, c := range p.columns {
+			headers = append(headers, c.Header)
+		}
+		if err := p.w.Write(headers); err != nil {
+			return err
+		}
+	}
+	for _, obj := range objs {
+		record := make([]string, 0, len(p.columns))
+		for _, c := range p.columns {
+			record = append(record, c.Value(obj))
+		}
+		if err := p.w.Write(record); err != nil {
+			return err
+		}
+	}
+	p.count += len(objs)
+	// Flushed per batch so paged lists stream into the pipe.
+	p.w.Flush()
+	return p.w.Error()
+}
+
+func (p *csvPrinter) Flush() error {
+	p.w.Flush()
+	return p.w.Error()
+}
+
+func (p *csvPrinter) Count() int { return p.count }
@@ -0,0 +1,92 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCSVPrinter(t *testing.T) {
+	pods := []runtime.Object{
+		testPod("default", "web", "node-1"),
+		testPod("kube-system", "dns, the resolver", "node-2"),
+	}
+	cluster := Column{Header: "CLUSTER", Value: func(runtime.Object) string { return "prod" }}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "all columns including wide ones",
+			opts: Options{Format: FormatCSV, Columns: testColumns},
+			want: `
+NAME,STATUS,NODE
+web,Running,node-1
+"dns, the resolver",Running,node-2
+`,
+		},
+		{
+			name: "selected columns in the given order",
+			opts: Options{Format: "csv=node,NAME", Columns: testColumns},
+			want: `
+NODE,NAME
+node-1,web
+node-2,"dns, the resolver"
+`,
+		},
+		{
+			name: "namespace selectable without all namespaces",
+			opts: Options{Format: "csv=NAMESPACE,NAME", Columns: testColumns},
+			want: `
+NAMESPACE,NAME
+default,web
+kube-system,"dns, the resolver"
+`,
+		},
+		{
+			name: "leading cluster column",
+			opts: Options{Format: FormatCSV, Columns: testColumns, Leading: []Column{cluster}, WithNamespace: true},
+			want: `
+CLUSTER,NAMESPACE,NAME,STATUS,NODE
+prod,default,web,Running,node-1
+prod,kube-system,"dns, the resolver",Running,node-2
+`,
+		},
+		{
+			name: "tsv",
+			opts: Options{Format: "tsv=NAME,STATUS", Columns: testColumns},
+			want: "NAME\tSTATUS\nweb\tRunning\ndns, the resolver\tRunning\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p, err := New(&out, tt.opts)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if err := p.PrintObjects(pods); err != nil {
+				t.Fatalf("PrintObjects() error = %v", err)
+			}
+			if err := p.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got, want := out.String(), strings.TrimPrefix(tt.want, "\n"); got != want {
+				t.Errorf("output =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestCSVPrinterUnknownColumn(t *testing.T) {
+	opts := Options{Format: "csv=NAME,IMAGE", Columns: testColumns, WithNamespace: true}
+	_, err := New(&bytes.Buffer{}, opts)
+	want := `unknown column "IMAGE", available columns: NAMESPACE,NAME,STATUS,NODE`
+	if err == nil || err.Error() != want {
+		t.Errorf("New() error = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,57 @@
+package printer
+
+import (
+	"encoding/json"
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// listPrinter prints JSON or YAML. Objects are buffered and written as one
+// v1 List on Flush, the same document kubectl produces for a list.
+type listPrinter struct {
+	out    io.Writer
+	yaml   bool
+	single bool
+	items  []runtime.Object
+}
+
+func (p *listPrinter) PrintObjects(objs []runtime.Object) error {
+	p.items = append(p.items, objs...)
+	return nil
+}
+
+func (p *listPrinter) Flush() error {
+	var doc interface{}
+	if p.single && len(p.items) == 1 {
+		doc = p.items[0]
+	} else {
+		items := p.items
+		if items == nil {
+			items = []runtime.Object{}
+		}
+		doc = map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"metadata":   map[string]interface{}{"resourceVersion": ""},
+			"items":      items,
+		}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return err
+	}
+	if p.yaml {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+	_, err = p.out.Write(data)
+	return err
+}
+
+func (p *listPrinter) Count() int { return len(p.items) }
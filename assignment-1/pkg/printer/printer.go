@@ -0,0 +1,128 @@
+// Package printer renders API objects for the k8sctl commands in the formats
+// selected with -o: a human readable table (optionally wide), JSON, YAML,
+// names only, user defined custom columns or CSV/TSV.
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Output formats accepted by New.
+const (
+	FormatTable         = ""
+	FormatWide          = "wide"
+	FormatJSON          = "json"
+	FormatYAML          = "yaml"
+	FormatName          = "name"
+	FormatCustomColumns = "custom-columns"
+	FormatCSV           = "csv"
+	FormatTSV           = "tsv"
+)
+
+// Column is one column of the table output.
+type Column struct {
+	Header string
+	// Wide columns are only shown with -o wide.
+	Wide bool
+	// Value renders the cell of the column for obj.
+	Value func(obj runtime.Object) string
+}
+
+// Options configure a Printer.
+type Options struct {
+	// Format is the value of the -o flag.
+	Format string
+	// Columns are the table columns shown after NAMESPACE and NAME.
+	Columns []Column
+	// Leading columns are shown first, before NAMESPACE and NAME, by the
+	// table and custom-columns formats. Multi-cluster output uses it for the
+	// CLUSTER column.
+	Leading []Column
+	// WithNamespace adds the NAMESPACE column to tables.
+	WithNamespace bool
+	// WithKind prefixes names in tables with the kind, e.g. when printing
+	// objects of several resource types at once.
+	WithKind bool
+	// WithoutName drops the NAME column of tables, for objects whose
+	// generated names tell nothing, such as events.
+	WithoutName bool
+	// SingleObject prints a lone object as itself instead of wrapping it in
+	// a List, as expected when a single object was requested by name.
+	SingleObject bool
+}
+
+// Printer prints objects, typically page by page as they arrive from the
+// API server. Objects must carry their apiVersion and kind.
+type Printer interface {
+	// PrintObjects prints a batch of objects.
+	PrintObjects(objs []runtime.Object) error
+	// Flush finishes the output, e.g. writes the buffered JSON/YAML list.
+	Flush() error
+	// Count returns how many objects were printed so far.
+	Count() int
+}
+
+// New returns the printer for opts.Format.
+func New(out io.Writer, opts Options) (Printer, error) {
+	format, arg, _ := strings.Cut(opts.Format, "=")
+	switch format {
+	case FormatTable, FormatWide:
+		return newTablePrinter(out, opts, format == FormatWide), nil
+	case FormatJSON, FormatYAML:
+		return &listPrinter{out: out, yaml: format == FormatYAML, single: opts.SingleObject}, nil
+	case FormatName:
+		return &namePrinter{out: out}, nil
+	case FormatCustomColumns:
+		return newCustomColumnsPrinter(out, arg, opts.Leading)
+	case FormatCSV:
+		return newCSVPrinter(out, opts, arg, ',')
+	case FormatTSV:
+		return newCSVPrinter(out, opts, arg, '\t')
+	}
+	return nil, fmt.Errorf("unknown output format %q, expected one of: json, yaml, wide, name, custom-columns=, csv, tsv", opts.Format)
+}
+
+// KindName renders an object as kind.group/name, e.g. deployment.apps/web.
+func KindName(obj runtime.Object) string {
+	name := ""
+	if accessor, err := meta.Accessor(obj); err == nil {
+		name = accessor.GetName()
+	}
+	return KindGroup(obj.GetObjectKind().GroupVersionKind()) + "/" + name
+}
+
+// KindGroup renders the lower cased kind qualified by its group, e.g.
+// "deployment.apps" or "pod" for the core group.
+func KindGroup(gvk schema.GroupVersionKind) string {
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		return kind + "." + gvk.Group
+	}
+	return kind
+}
+
+// namePrinter prints kind.group/name, one object per line.
+type namePrinter struct {
+	out   io.Writer
+	count int
+}
+
+func (p *namePrinter) PrintObjects(objs []runtime.Object) error {
+	for _, obj := range objs {
+		if _, err := fmt.Fprintln(p.out, KindName(obj)); err != nil {
+			return err
+		}
+	}
+	p.count += len(objs)
+	return nil
+}
+
+func (p *namePrinter) Flush() error { return nil }
+
+func (p *namePrinter) Count() int { return p.count }
@@ -0,0 +1,194 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testPod(namespace, name, node string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			NodeName:   node,
+			Containers: []corev1.Container{{Name: "web", Image: "nginx:1.27"}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+var testColumns = []Column{
+	{Header: "STATUS", Value: func(obj runtime.Object) string { return string(obj.(*corev1.Pod).Status.Phase) }},
+	{Header: "NODE", Wide: true, Value: func(obj runtime.Object) string { return obj.(*corev1.Pod).Spec.NodeName }},
+}
+
+func TestPrinter(t *testing.T) {
+	pods := []runtime.Object{
+		testPod("default", "web", "node-1"),
+		testPod("kube-system", "dns", "node-2"),
+	}
+	cluster := Column{Header: "CLUSTER", Value: func(runtime.Object) string { return "prod" }}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "table",
+			opts: Options{Columns: testColumns},
+			want: `
+NAME   STATUS
+web    Running
+dns    Running
+`,
+		},
+		{
+			name: "wide",
+			opts: Options{Format: FormatWide, Columns: testColumns},
+			want: `
+NAME   STATUS    NODE
+web    Running   node-1
+dns    Running   node-2
+`,
+		},
+		{
+			name: "table with namespace and kind",
+			opts: Options{Columns: testColumns, WithNamespace: true, WithKind: true},
+			want: `
+NAMESPACE     NAME      STATUS
+default       pod/web   Running
+kube-system   pod/dns   Running
+`,
+		},
+		{
+			name: "table without name",
+			opts: Options{Columns: testColumns, WithoutName: true},
+			want: `
+STATUS
+Running
+Running
+`,
+		},
+		{
+			name: "table with leading cluster column",
+			opts: Options{Columns: testColumns, Leading: []Column{cluster}},
+			want: `
+CLUSTER   NAME   STATUS
+prod      web    Running
+prod      dns    Running
+`,
+		},
+		{
+			name: "name",
+			opts: Options{Format: FormatName, Columns: testColumns},
+			want: `
+pod/web
+pod/dns
+`,
+		},
+		{
+			name: "custom columns",
+			opts: Options{Format: "custom-columns=NAME:.metadata.name,IMAGE:.spec.containers[*].image,IP:.status.podIP"},
+			want: `
+NAME   IMAGE        IP
+web    nginx:1.27   <none>
+dns    nginx:1.27   <none>
+`,
+		},
+		{
+			name: "custom columns with leading cluster column",
+			opts: Options{Format: "custom-columns=NAME:{.metadata.name}", Leading: []Column{cluster}},
+			want: `
+CLUSTER   NAME
+prod      web
+prod      dns
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p, err := New(&out, tt.opts)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if err := p.PrintObjects(pods); err != nil {
+				t.Fatalf("PrintObjects() error = %v", err)
+			}
+			if err := p.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got, want := out.String(), strings.TrimPrefix(tt.want, "\n"); got != want {
+				t.Errorf("output =\n%s\nwant\n%s", got, want)
+			}
+			if got := p.Count(); got != len(pods) {
+				t.Errorf("Count() = %d, want %d", got, len(pods))
+			}
+		})
+	}
+}
+
+func TestPrinterHeaderOnce(t *testing.T) {
+	var out bytes.Buffer
+	p, err := New(&out, Options{Columns: testColumns})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for _, page := range [][]runtime.Object{{testPod("default", "a", "")}, {}, {testPod("default", "b", "")}} {
+		if err := p.PrintObjects(page); err != nil {
+			t.Fatalf("PrintObjects() error = %v", err)
+		}
+	}
+	if got := strings.Count(out.String(), "NAME"); got != 1 {
+		t.Errorf("header printed %d times, want 1:\n%s", got, out.String())
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{name: "unknown format", format: "xml", want: `unknown output format "xml"`},
+		{name: "custom columns without spec", format: "custom-columns", want: "requires a spec"},
+		{name: "custom column without path", format: "custom-columns=NAME", want: `invalid custom column "NAME"`},
+		{name: "invalid JSONPath", format: "custom-columns=NAME:.metadata[", want: "invalid JSONPath"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&bytes.Buffer{}, Options{Format: tt.format})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKindName(t *testing.T) {
+	tests := []struct {
+		obj  runtime.Object
+		want string
+	}{
+		{obj: testPod("default", "web", ""), want: "pod/web"},
+		{
+			obj: &appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			},
+			want: "deployment.apps/web",
+		},
+	}
+	for _, tt := range tests {
+		if got := KindName(tt.obj); got != tt.want {
+			t.Errorf("KindName() = %q, want %q", got, tt.want)
+		}
+	}
+}
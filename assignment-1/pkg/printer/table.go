@@ -0,0 +1,85 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// tablePrinter prints aligned columns. Every batch is flushed as soon as it is
+// printed, so alignment is computed per page of a paged list.
+type tablePrinter struct {
+	out     io.Writer
+	opts    Options
+	columns []Column
+	count   int
+}
+
+func newTablePrinter(out io.Writer, opts Options, wide bool) *tablePrinter {
+	p := &tablePrinter{out: out, opts: opts}
+	for _, c := range opts.Columns {
+		if !c.Wide || wide {
+			p.columns = append(p.columns, c)
+		}
+	}
+	return p
+}
+
+func (p *tablePrinter) PrintObjects(objs []runtime.Object) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 3, ' ', 0)
+	if p.count == 0 {
+		headers := make([]string, 0, len(p.opts.Leading)+len(p.columns)+2)
+		for _, c := range p.opts.Leading {
+			headers = append(headers, c.Header)
+		}
+		if p.opts.WithNamespace {
+			headers = append(headers, "NAMESPACE")
+		}
+		if !p.opts.WithoutName {
+			headers = append(headers, "NAME")
+		}
+		for _, c := range p.columns {
+			headers = append(headers, c.Header)
+		}
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objs {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		cells := make([]string, 0, len(p.opts.Leading)+len(p.columns)+2)
+		for _, c := range p.opts.Leading {
+			cells = append(cells, c.Value(obj))
+		}
+		if p.opts.WithNamespace {
+			cells = append(cells, accessor.GetNamespace())
+		}
+		switch {
+		case p.opts.WithoutName:
+		case p.opts.WithKind:
+			cells = append(cells, KindName(obj))
+		default:
+			cells = append(cells, accessor.GetName())
+		}
+		for _, c := range p.columns {
+			cells = append(cells, c.Value(obj))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	p.count += len(objs)
+	return w.Flush()
+}
+
+func (p *tablePrinter) Flush() error { return nil }
+
+func (p *tablePrinter) Count() int { return p.count }